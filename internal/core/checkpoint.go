@@ -0,0 +1,103 @@
+package core
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+
+	zlog "github.com/rs/zerolog/log"
+)
+
+// CheckpointSchemaVersion identifies the on-disk format of CheckpointRecord,
+// so the format can evolve without breaking older checkpoint files.
+const CheckpointSchemaVersion = 1
+
+// CheckpointRecord captures how far a single target has progressed through
+// the password list. Checkpoint files are append-only JSONL: later records
+// for the same target supersede earlier ones.
+type CheckpointRecord struct {
+	Version           int    `json:"version"`
+	Target            string `json:"target"`
+	Username          string `json:"username"`
+	LastPasswordIndex int    `json:"last_password_index"`
+	Completed         bool   `json:"completed"`
+	Success           bool   `json:"success"`
+}
+
+// CheckpointWriter appends CheckpointRecords to a JSONL file, fsyncing after
+// every write so an interrupted process doesn't lose the last recorded
+// progress.
+type CheckpointWriter struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+// NewCheckpointWriter opens (creating if necessary) path for append-only
+// checkpoint writes.
+func NewCheckpointWriter(path string) (*CheckpointWriter, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("opening checkpoint file %q: %w", path, err)
+	}
+	return &CheckpointWriter{file: f}, nil
+}
+
+// Write appends a single checkpoint record and fsyncs it to disk.
+func (cw *CheckpointWriter) Write(record CheckpointRecord) error {
+	cw.mu.Lock()
+	defer cw.mu.Unlock()
+
+	record.Version = CheckpointSchemaVersion
+	data, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("marshaling checkpoint record: %w", err)
+	}
+	data = append(data, '\n')
+	if _, err := cw.file.Write(data); err != nil {
+		return fmt.Errorf("writing checkpoint record: %w", err)
+	}
+	return cw.file.Sync()
+}
+
+// Close flushes and closes the checkpoint file.
+func (cw *CheckpointWriter) Close() error {
+	cw.mu.Lock()
+	defer cw.mu.Unlock()
+	return cw.file.Close()
+}
+
+// LoadCheckpoint reads a checkpoint file and returns the most recent record
+// for each target, keyed by target address. A missing file is treated as an
+// empty checkpoint rather than an error, since the first run of a new
+// --checkpoint path hasn't created one yet.
+func LoadCheckpoint(path string) (map[string]CheckpointRecord, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return map[string]CheckpointRecord{}, nil
+		}
+		return nil, fmt.Errorf("opening checkpoint file %q: %w", path, err)
+	}
+	defer f.Close()
+
+	records := make(map[string]CheckpointRecord)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		var record CheckpointRecord
+		if err := json.Unmarshal(line, &record); err != nil {
+			zlog.Warn().Err(err).Msg("Skipping malformed checkpoint line")
+			continue
+		}
+		records[record.Target] = record
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading checkpoint file %q: %w", path, err)
+	}
+	return records, nil
+}
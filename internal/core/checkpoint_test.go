@@ -0,0 +1,99 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCheckpointWriterWriteAndLoad(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "checkpoint-test-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer func() {
+		if err := os.RemoveAll(tmpDir); err != nil {
+			t.Logf("Failed to remove temp dir: %v", err)
+		}
+	}()
+
+	path := filepath.Join(tmpDir, "checkpoint.jsonl")
+
+	writer, err := NewCheckpointWriter(path)
+	if err != nil {
+		t.Fatalf("Failed to open checkpoint writer: %v", err)
+	}
+
+	if err := writer.Write(CheckpointRecord{
+		Target:            "192.168.1.1",
+		Username:          "admin",
+		LastPasswordIndex: 150,
+		Completed:         false,
+		Success:           false,
+	}); err != nil {
+		t.Fatalf("Failed to write checkpoint record: %v", err)
+	}
+
+	// A later record for the same target supersedes the earlier one.
+	if err := writer.Write(CheckpointRecord{
+		Target:            "192.168.1.1",
+		Username:          "admin",
+		LastPasswordIndex: 300,
+		Completed:         true,
+		Success:           true,
+	}); err != nil {
+		t.Fatalf("Failed to write checkpoint record: %v", err)
+	}
+
+	if err := writer.Write(CheckpointRecord{
+		Target:            "192.168.1.2",
+		Username:          "admin",
+		LastPasswordIndex: 42,
+		Completed:         false,
+		Success:           false,
+	}); err != nil {
+		t.Fatalf("Failed to write checkpoint record: %v", err)
+	}
+
+	if err := writer.Close(); err != nil {
+		t.Fatalf("Failed to close checkpoint writer: %v", err)
+	}
+
+	records, err := LoadCheckpoint(path)
+	if err != nil {
+		t.Fatalf("Failed to load checkpoint: %v", err)
+	}
+
+	if len(records) != 2 {
+		t.Fatalf("Expected 2 targets in checkpoint, got %d", len(records))
+	}
+
+	completed, ok := records["192.168.1.1"]
+	if !ok {
+		t.Fatal("Expected checkpoint record for 192.168.1.1")
+	}
+	if !completed.Completed || !completed.Success || completed.LastPasswordIndex != 300 {
+		t.Errorf("Expected superseded record for 192.168.1.1, got %+v", completed)
+	}
+	if completed.Version != CheckpointSchemaVersion {
+		t.Errorf("Expected schema version %d, got %d", CheckpointSchemaVersion, completed.Version)
+	}
+
+	inProgress, ok := records["192.168.1.2"]
+	if !ok {
+		t.Fatal("Expected checkpoint record for 192.168.1.2")
+	}
+	if inProgress.Completed || inProgress.LastPasswordIndex != 42 {
+		t.Errorf("Expected in-progress record for 192.168.1.2, got %+v", inProgress)
+	}
+}
+
+func TestLoadCheckpointMissingFile(t *testing.T) {
+	records, err := LoadCheckpoint(filepath.Join(t.TempDir(), "does-not-exist.jsonl"))
+	if err != nil {
+		t.Fatalf("Expected missing checkpoint file to be treated as empty, got error: %v", err)
+	}
+	if len(records) != 0 {
+		t.Errorf("Expected no records from missing file, got %d", len(records))
+	}
+}
@@ -0,0 +1,163 @@
+package core
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/nimda/router-brute/internal/interfaces"
+	"github.com/spf13/viper"
+)
+
+// moduleAliases maps the short module keys used in --config files to the
+// full protocol names registered in interfaces.DefaultRegistry by each
+// module package's init().
+var moduleAliases = map[string]string{
+	"v6":      "mikrotik-v6",
+	"v7":      "mikrotik-v7",
+	"v7-rest": "mikrotik-v7-rest",
+	"winbox":  "mikrotik-winbox",
+}
+
+// LookupModule resolves a short --config module key (v6, v7, v7-rest,
+// winbox) to its registered protocol info, including the Factory to use
+// for a target with that module.
+func LookupModule(module string) (interfaces.ProtocolInfo, bool) {
+	name, ok := moduleAliases[module]
+	if !ok {
+		return interfaces.ProtocolInfo{}, false
+	}
+	return interfaces.DefaultRegistry.Get(name)
+}
+
+// RunConfig is the schema for --config files (YAML or TOML, parsed via
+// viper). It supplies run-wide defaults plus a per-target override list so
+// a single invocation can drive a heterogeneous fleet of mixed protocols
+// and ports.
+type RunConfig struct {
+	Workers            int    `mapstructure:"workers"`
+	Rate               string `mapstructure:"rate"`
+	ConcurrentTargets  int    `mapstructure:"concurrent_targets"`
+	Checkpoint         string `mapstructure:"checkpoint"`
+	CheckpointInterval int    `mapstructure:"checkpoint_interval"`
+	Resume             bool   `mapstructure:"resume"`
+	Output             string `mapstructure:"output"`
+	OutputFormat       string `mapstructure:"output_format"`
+	ProgressFile       string `mapstructure:"progress_file"`
+
+	// Per-target defaults, overridable by each entry in Targets.
+	User     string `mapstructure:"user"`
+	Port     int    `mapstructure:"port"`
+	HTTPS    bool   `mapstructure:"https"`
+	Timeout  string `mapstructure:"timeout"`
+	Wordlist string `mapstructure:"wordlist"`
+	Module   string `mapstructure:"module"`
+
+	Targets []TargetOverride `mapstructure:"targets"`
+}
+
+// TargetOverride is a single entry in a RunConfig's targets list. Any zero
+// value falls back to the RunConfig-level default for that field.
+type TargetOverride struct {
+	IP       string `mapstructure:"ip"`
+	User     string `mapstructure:"user"`
+	Port     int    `mapstructure:"port"`
+	HTTPS    *bool  `mapstructure:"https"`
+	Timeout  string `mapstructure:"timeout"`
+	Wordlist string `mapstructure:"wordlist"`
+	Module   string `mapstructure:"module"`
+}
+
+// ResolvedTarget is a TargetOverride fully merged with its RunConfig
+// defaults: everything needed to dial and attack one target.
+type ResolvedTarget struct {
+	IP       string
+	User     string
+	Port     int
+	HTTPS    bool
+	Timeout  time.Duration
+	Wordlist string
+	Module   string
+}
+
+// LoadRunConfig reads and parses a --config file. The format (YAML or
+// TOML) is inferred by viper from the file extension.
+func LoadRunConfig(path string) (*RunConfig, error) {
+	v := viper.New()
+	v.SetConfigFile(path)
+	if err := v.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("reading config file %q: %w", path, err)
+	}
+
+	var cfg RunConfig
+	if err := v.Unmarshal(&cfg); err != nil {
+		return nil, fmt.Errorf("parsing config file %q: %w", path, err)
+	}
+	return &cfg, nil
+}
+
+// Resolve merges each target override with the RunConfig's defaults,
+// producing the concrete set of targets to attack.
+func (c *RunConfig) Resolve() ([]ResolvedTarget, error) {
+	resolved := make([]ResolvedTarget, 0, len(c.Targets))
+	for _, t := range c.Targets {
+		if t.IP == "" {
+			return nil, fmt.Errorf("target entry missing required \"ip\" field")
+		}
+
+		module := t.Module
+		if module == "" {
+			module = c.Module
+		}
+		if module == "" {
+			return nil, fmt.Errorf("target %s: no module specified (set \"module\" on the target or as a config default)", t.IP)
+		}
+		info, ok := LookupModule(module)
+		if !ok {
+			return nil, fmt.Errorf("target %s: unknown module %q", t.IP, module)
+		}
+
+		rt := ResolvedTarget{
+			IP:       t.IP,
+			User:     firstNonEmpty(t.User, c.User),
+			Port:     firstNonZero(t.Port, c.Port, info.DefaultPort),
+			HTTPS:    c.HTTPS,
+			Wordlist: firstNonEmpty(t.Wordlist, c.Wordlist),
+			Module:   module,
+		}
+		if t.HTTPS != nil {
+			rt.HTTPS = *t.HTTPS
+		}
+
+		timeoutStr := firstNonEmpty(t.Timeout, c.Timeout, "10s")
+		timeout, err := time.ParseDuration(timeoutStr)
+		if err != nil {
+			return nil, fmt.Errorf("target %s: invalid timeout %q: %w", t.IP, timeoutStr, err)
+		}
+		rt.Timeout = timeout
+
+		if rt.Wordlist == "" {
+			return nil, fmt.Errorf("target %s: no wordlist specified (set \"wordlist\" on the target or as a config default)", t.IP)
+		}
+
+		resolved = append(resolved, rt)
+	}
+	return resolved, nil
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+func firstNonZero(values ...int) int {
+	for _, v := range values {
+		if v != 0 {
+			return v
+		}
+	}
+	return 0
+}
@@ -0,0 +1,105 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nimda/router-brute/internal/interfaces"
+)
+
+// The real protocol modules live under internal/modules, which imports
+// internal/core, so they can't be imported here without an import cycle.
+// Register minimal stand-ins under the same registry names instead.
+func init() {
+	_ = interfaces.Register(interfaces.ProtocolInfo{
+		Name:        "mikrotik-v6",
+		DefaultPort: 8728,
+		Factory:     func() interfaces.RouterModule { return nil },
+	})
+	_ = interfaces.Register(interfaces.ProtocolInfo{
+		Name:        "mikrotik-v7-rest",
+		DefaultPort: 80,
+		Factory:     func() interfaces.RouterModule { return nil },
+	})
+}
+
+func TestLoadRunConfigAndResolve(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "fleet.yaml")
+
+	yaml := `
+workers: 8
+rate: 50ms
+user: admin
+wordlist: default.txt
+module: v6
+targets:
+  - ip: 192.168.1.1
+  - ip: 192.168.1.2
+    user: root
+    module: v7-rest
+    https: true
+    port: 8443
+    wordlist: other.txt
+`
+	if err := os.WriteFile(configPath, []byte(yaml), 0644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+
+	cfg, err := LoadRunConfig(configPath)
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	if cfg.Workers != 8 || cfg.Wordlist != "default.txt" {
+		t.Fatalf("Unexpected config defaults: %+v", cfg)
+	}
+
+	resolved, err := cfg.Resolve()
+	if err != nil {
+		t.Fatalf("Failed to resolve targets: %v", err)
+	}
+	if len(resolved) != 2 {
+		t.Fatalf("Expected 2 resolved targets, got %d", len(resolved))
+	}
+
+	first := resolved[0]
+	if first.IP != "192.168.1.1" || first.User != "admin" || first.Module != "v6" || first.Wordlist != "default.txt" {
+		t.Errorf("Expected first target to inherit defaults, got %+v", first)
+	}
+
+	second := resolved[1]
+	if second.IP != "192.168.1.2" || second.User != "root" || second.Module != "v7-rest" {
+		t.Errorf("Expected second target overrides applied, got %+v", second)
+	}
+	if !second.HTTPS || second.Port != 8443 || second.Wordlist != "other.txt" {
+		t.Errorf("Expected second target per-field overrides applied, got %+v", second)
+	}
+}
+
+func TestRunConfigResolveMissingModule(t *testing.T) {
+	cfg := &RunConfig{
+		Wordlist: "default.txt",
+		Targets: []TargetOverride{
+			{IP: "192.168.1.1"},
+		},
+	}
+
+	if _, err := cfg.Resolve(); err == nil {
+		t.Fatal("Expected an error when no module is specified")
+	}
+}
+
+func TestRunConfigResolveUnknownModule(t *testing.T) {
+	cfg := &RunConfig{
+		Wordlist: "default.txt",
+		Module:   "not-a-real-module",
+		Targets: []TargetOverride{
+			{IP: "192.168.1.1"},
+		},
+	}
+
+	if _, err := cfg.Resolve(); err == nil {
+		t.Fatal("Expected an error for an unknown module")
+	}
+}
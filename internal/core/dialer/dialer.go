@@ -0,0 +1,189 @@
+// Package dialer provides a rotating network dialer used by every brute-force
+// module to diversify the attacker's apparent source address: a pool of
+// local source IPs and/or upstream proxies (SOCKS5 or HTTP), rotated
+// round-robin or randomly so consecutive attempts from the same worker
+// emerge from different sources.
+package dialer
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"golang.org/x/net/proxy"
+)
+
+// Dialer dials through a shared Pool, recording the identity (source IP
+// and/or proxy) used for its most recent dial so a module can surface it on
+// core.Result.
+type Dialer struct {
+	pool    *Pool
+	timeout time.Duration
+
+	mu           sync.Mutex
+	lastIdentity string
+}
+
+// New creates a Dialer backed by pool. timeout bounds each individual dial
+// (and, for a proxied dial, the proxy handshake).
+func New(pool *Pool, timeout time.Duration) *Dialer {
+	return &Dialer{pool: pool, timeout: timeout}
+}
+
+// LastIdentity returns the source IP / proxy identity used by the most
+// recent successful DialContext call, or "" if none has succeeded yet (or
+// the pool has neither source IPs nor proxies configured).
+func (d *Dialer) LastIdentity() string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.lastIdentity
+}
+
+// DialContext dials addr, rotating through the pool's configured source IPs
+// and/or proxies.
+func (d *Dialer) DialContext(ctx context.Context, network, addr string) (net.Conn, error) {
+	sourceIP, proxyHealth := d.pool.next()
+
+	forward := &net.Dialer{Timeout: d.timeout}
+	if sourceIP != nil {
+		forward.LocalAddr = &net.TCPAddr{IP: sourceIP}
+	}
+
+	var conn net.Conn
+	var err error
+	switch {
+	case proxyHealth == nil:
+		conn, err = forward.DialContext(ctx, network, addr)
+	case proxyHealth.proxy.Kind == KindHTTP:
+		conn, err = dialViaHTTPConnect(ctx, forward, proxyHealth.proxy, addr)
+	default:
+		conn, err = dialViaSOCKS5(ctx, forward, proxyHealth.proxy, network, addr)
+	}
+
+	if proxyHealth != nil {
+		d.pool.reportResult(proxyHealth, err)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	identity := identityOf(sourceIP, proxyHealth)
+	d.mu.Lock()
+	d.lastIdentity = identity
+	d.mu.Unlock()
+	return conn, nil
+}
+
+func identityOf(sourceIP net.IP, proxyHealth *proxyHealth) string {
+	switch {
+	case sourceIP != nil && proxyHealth != nil:
+		return fmt.Sprintf("%s via %s", sourceIP, proxyHealth.proxy)
+	case proxyHealth != nil:
+		return proxyHealth.proxy.String()
+	case sourceIP != nil:
+		return sourceIP.String()
+	default:
+		return ""
+	}
+}
+
+// RoundTripper returns an http.RoundTripper that dials through d, for use by
+// the REST module. Keep-alives are disabled so every request gets a fresh
+// dial, and thus a fresh rotation, rather than reusing a pooled connection.
+func (d *Dialer) RoundTripper() http.RoundTripper {
+	return &http.Transport{
+		DialContext:       d.DialContext,
+		DisableKeepAlives: true,
+	}
+}
+
+// dialViaSOCKS5 connects to addr through a SOCKS5 proxy. For KindSOCKS5 the
+// target host is resolved locally first (the proxy only ever sees an IP);
+// for KindSOCKS5H the hostname is handed to the proxy unresolved.
+func dialViaSOCKS5(ctx context.Context, forward *net.Dialer, p Proxy, network, addr string) (net.Conn, error) {
+	var auth *proxy.Auth
+	if p.URL.User != nil {
+		password, _ := p.URL.User.Password()
+		auth = &proxy.Auth{User: p.URL.User.Username(), Password: password}
+	}
+
+	socksDialer, err := proxy.SOCKS5("tcp", p.URL.Host, auth, forward)
+	if err != nil {
+		return nil, err
+	}
+
+	target := addr
+	if p.Kind == KindSOCKS5 {
+		host, port, err := net.SplitHostPort(addr)
+		if err != nil {
+			return nil, err
+		}
+		if net.ParseIP(host) == nil {
+			ips, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+			if err != nil {
+				return nil, fmt.Errorf("resolving %q for socks5 proxy: %w", host, err)
+			}
+			host = ips[0].IP.String()
+		}
+		target = net.JoinHostPort(host, port)
+	}
+
+	contextDialer, ok := socksDialer.(proxy.ContextDialer)
+	if !ok {
+		// Unreachable with the x/net/proxy implementation, but fall back to
+		// the non-context Dial rather than panic if that ever changes.
+		return socksDialer.Dial(network, target)
+	}
+	return contextDialer.DialContext(ctx, network, target)
+}
+
+// dialViaHTTPConnect tunnels a connection to addr through an HTTP proxy
+// using the CONNECT method.
+func dialViaHTTPConnect(ctx context.Context, forward *net.Dialer, p Proxy, addr string) (net.Conn, error) {
+	conn, err := forward.DialContext(ctx, "tcp", p.URL.Host)
+	if err != nil {
+		return nil, err
+	}
+
+	connectReq := &http.Request{
+		Method: http.MethodConnect,
+		URL:    &url.URL{Opaque: addr},
+		Host:   addr,
+		Header: make(http.Header),
+	}
+	if p.URL.User != nil {
+		password, _ := p.URL.User.Password()
+		connectReq.SetBasicAuth(p.URL.User.Username(), password)
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetDeadline(deadline); err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+
+	if err := connectReq.Write(conn); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	resp, err := http.ReadResponse(bufio.NewReader(conn), connectReq)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		conn.Close()
+		return nil, fmt.Errorf("http proxy %s: CONNECT %s: %s", p.URL.Host, addr, resp.Status)
+	}
+
+	return conn, nil
+}
@@ -0,0 +1,67 @@
+package dialer
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+)
+
+func TestDialerDialContextDirectRecordsIdentity(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen: %v", err)
+	}
+	defer ln.Close()
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			conn.Close()
+		}
+	}()
+
+	pool, err := NewPool([]string{"127.0.0.1"}, nil, RoundRobin)
+	if err != nil {
+		t.Fatalf("NewPool: %v", err)
+	}
+	d := New(pool, 2*time.Second)
+
+	if got := d.LastIdentity(); got != "" {
+		t.Errorf("LastIdentity() before any dial = %q, want \"\"", got)
+	}
+
+	conn, err := d.DialContext(context.Background(), "tcp", ln.Addr().String())
+	if err != nil {
+		t.Fatalf("DialContext: %v", err)
+	}
+	conn.Close()
+
+	if got, want := d.LastIdentity(), "127.0.0.1"; got != want {
+		t.Errorf("LastIdentity() = %q, want %q", got, want)
+	}
+}
+
+func TestIdentityOf(t *testing.T) {
+	p := mustProxy(t, "socks5://10.0.0.9:1080")
+	h := &proxyHealth{proxy: p}
+
+	tests := []struct {
+		name string
+		ip   net.IP
+		h    *proxyHealth
+		want string
+	}{
+		{"neither", nil, nil, ""},
+		{"ip only", net.ParseIP("10.0.0.1"), nil, "10.0.0.1"},
+		{"proxy only", nil, h, "socks5://10.0.0.9:1080"},
+		{"both", net.ParseIP("10.0.0.1"), h, "10.0.0.1 via socks5://10.0.0.9:1080"},
+	}
+	for _, tt := range tests {
+		if got := identityOf(tt.ip, tt.h); got != tt.want {
+			t.Errorf("%s: identityOf() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,164 @@
+package dialer
+
+import (
+	"fmt"
+	"math/rand"
+	"net"
+	"sync"
+	"time"
+)
+
+// Strategy selects how the next source IP / proxy in a Pool is picked.
+type Strategy string
+
+const (
+	RoundRobin Strategy = "round-robin"
+	Random     Strategy = "random"
+)
+
+// defaultFailThreshold and defaultEvictionCooldown control a proxy's
+// temporary eviction: after this many consecutive dial failures through it,
+// the proxy is skipped for the cooldown period before being retried.
+const (
+	defaultFailThreshold    = 3
+	defaultEvictionCooldown = 30 * time.Second
+)
+
+// proxyHealth tracks consecutive dial failures for one pooled proxy.
+type proxyHealth struct {
+	proxy               Proxy
+	consecutiveFailures int
+	evictedUntil        time.Time
+}
+
+// Pool holds the set of source IPs and/or upstream proxies a Dialer rotates
+// through. Source IPs and proxies rotate independently: a dial can combine
+// a rotated local source address with a rotated proxy, either alone, or
+// neither (a plain direct dial).
+type Pool struct {
+	mu sync.Mutex
+
+	sourceIPs []net.IP
+	sourceIdx int
+
+	proxies  []*proxyHealth
+	proxyIdx int
+
+	strategy Strategy
+	rng      *rand.Rand
+
+	failThreshold int
+	cooldown      time.Duration
+}
+
+// NewPool builds a Pool from the string forms of --source-ips and the
+// already-parsed --proxy/--proxy-file entries. An empty sourceIPs/proxies
+// means that knob contributes nothing (no LocalAddr, or no proxy) to a dial.
+func NewPool(sourceIPs []string, proxies []Proxy, strategy Strategy) (*Pool, error) {
+	if strategy == "" {
+		strategy = RoundRobin
+	}
+	if strategy != RoundRobin && strategy != Random {
+		return nil, fmt.Errorf("unknown proxy strategy %q", strategy)
+	}
+
+	ips := make([]net.IP, 0, len(sourceIPs))
+	for _, s := range sourceIPs {
+		ip := net.ParseIP(s)
+		if ip == nil {
+			return nil, fmt.Errorf("invalid source IP %q", s)
+		}
+		ips = append(ips, ip)
+	}
+
+	health := make([]*proxyHealth, len(proxies))
+	for i, p := range proxies {
+		health[i] = &proxyHealth{proxy: p}
+	}
+
+	return &Pool{
+		sourceIPs:     ips,
+		proxies:       health,
+		strategy:      strategy,
+		rng:           rand.New(rand.NewSource(time.Now().UnixNano())),
+		failThreshold: defaultFailThreshold,
+		cooldown:      defaultEvictionCooldown,
+	}, nil
+}
+
+// Empty reports whether the pool has neither source IPs nor proxies
+// configured, i.e. every dial is a plain direct dial.
+func (p *Pool) Empty() bool {
+	return len(p.sourceIPs) == 0 && len(p.proxies) == 0
+}
+
+// next picks the source IP and/or proxy for one dial. A nil *proxyHealth
+// means no (healthy) proxy was available; callers should dial directly.
+func (p *Pool) next() (net.IP, *proxyHealth) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var sourceIP net.IP
+	if len(p.sourceIPs) > 0 {
+		sourceIP = p.pickSourceIPLocked()
+	}
+
+	var proxy *proxyHealth
+	if len(p.proxies) > 0 {
+		proxy = p.pickProxyLocked()
+	}
+
+	return sourceIP, proxy
+}
+
+func (p *Pool) pickSourceIPLocked() net.IP {
+	var idx int
+	if p.strategy == Random {
+		idx = p.rng.Intn(len(p.sourceIPs))
+	} else {
+		idx = p.sourceIdx % len(p.sourceIPs)
+		p.sourceIdx++
+	}
+	return p.sourceIPs[idx]
+}
+
+// pickProxyLocked returns the next healthy (not currently evicted) proxy, or
+// nil if every proxy is evicted right now.
+func (p *Pool) pickProxyLocked() *proxyHealth {
+	now := time.Now()
+	healthy := make([]int, 0, len(p.proxies))
+	for i, h := range p.proxies {
+		if h.evictedUntil.IsZero() || now.After(h.evictedUntil) {
+			healthy = append(healthy, i)
+		}
+	}
+	if len(healthy) == 0 {
+		return nil
+	}
+
+	if p.strategy == Random {
+		return p.proxies[healthy[p.rng.Intn(len(healthy))]]
+	}
+
+	idx := healthy[p.proxyIdx%len(healthy)]
+	p.proxyIdx++
+	return p.proxies[idx]
+}
+
+// reportResult records whether a dial through h succeeded, evicting h for
+// p.cooldown once it accumulates p.failThreshold consecutive failures.
+func (p *Pool) reportResult(h *proxyHealth, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if err == nil {
+		h.consecutiveFailures = 0
+		h.evictedUntil = time.Time{}
+		return
+	}
+
+	h.consecutiveFailures++
+	if h.consecutiveFailures >= p.failThreshold {
+		h.evictedUntil = time.Now().Add(p.cooldown)
+	}
+}
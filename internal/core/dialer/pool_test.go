@@ -0,0 +1,127 @@
+package dialer
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func mustProxy(t *testing.T, raw string) Proxy {
+	t.Helper()
+	p, err := ParseProxy(raw)
+	if err != nil {
+		t.Fatalf("ParseProxy(%q): %v", raw, err)
+	}
+	return p
+}
+
+func TestPoolRoundRobinSourceIPs(t *testing.T) {
+	pool, err := NewPool([]string{"10.0.0.1", "10.0.0.2"}, nil, RoundRobin)
+	if err != nil {
+		t.Fatalf("NewPool: %v", err)
+	}
+
+	var got []string
+	for i := 0; i < 4; i++ {
+		ip, _ := pool.next()
+		got = append(got, ip.String())
+	}
+	want := []string{"10.0.0.1", "10.0.0.2", "10.0.0.1", "10.0.0.2"}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("got[%d] = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
+
+func TestPoolRoundRobinProxies(t *testing.T) {
+	proxies := []Proxy{mustProxy(t, "socks5://10.0.0.1:1080"), mustProxy(t, "socks5://10.0.0.2:1080")}
+	pool, err := NewPool(nil, proxies, RoundRobin)
+	if err != nil {
+		t.Fatalf("NewPool: %v", err)
+	}
+
+	_, h1 := pool.next()
+	_, h2 := pool.next()
+	_, h3 := pool.next()
+	if h1.proxy.URL.Host == h2.proxy.URL.Host {
+		t.Fatalf("expected alternation, got %q then %q", h1.proxy.URL.Host, h2.proxy.URL.Host)
+	}
+	if h1.proxy.URL.Host != h3.proxy.URL.Host {
+		t.Errorf("expected round-robin to cycle back, got %q then %q", h1.proxy.URL.Host, h3.proxy.URL.Host)
+	}
+}
+
+func TestPoolEmpty(t *testing.T) {
+	pool, err := NewPool(nil, nil, RoundRobin)
+	if err != nil {
+		t.Fatalf("NewPool: %v", err)
+	}
+	if !pool.Empty() {
+		t.Error("Empty() = false, want true")
+	}
+	ip, h := pool.next()
+	if ip != nil || h != nil {
+		t.Errorf("next() on empty pool = (%v, %v), want (nil, nil)", ip, h)
+	}
+}
+
+func TestPoolRejectsUnknownStrategy(t *testing.T) {
+	if _, err := NewPool(nil, nil, Strategy("bogus")); err == nil {
+		t.Fatal("expected error for unknown strategy")
+	}
+}
+
+func TestPoolRejectsInvalidSourceIP(t *testing.T) {
+	if _, err := NewPool([]string{"not-an-ip"}, nil, RoundRobin); err == nil {
+		t.Fatal("expected error for invalid source IP")
+	}
+}
+
+func TestPoolEvictsAfterConsecutiveFailures(t *testing.T) {
+	proxies := []Proxy{mustProxy(t, "socks5://10.0.0.1:1080"), mustProxy(t, "socks5://10.0.0.2:1080")}
+	pool, err := NewPool(nil, proxies, RoundRobin)
+	if err != nil {
+		t.Fatalf("NewPool: %v", err)
+	}
+	pool.failThreshold = 2
+	pool.cooldown = time.Hour
+
+	_, h := pool.next()
+	target := h.proxy.URL.Host
+
+	for i := 0; i < 2; i++ {
+		var hh *proxyHealth
+		for {
+			_, hh = pool.next()
+			if hh.proxy.URL.Host == target {
+				break
+			}
+		}
+		pool.reportResult(hh, errors.New("dial failed"))
+	}
+
+	for i := 0; i < 4; i++ {
+		_, h := pool.next()
+		if h.proxy.URL.Host == target {
+			t.Fatalf("evicted proxy %q was still selected", target)
+		}
+	}
+}
+
+func TestPoolReportResultClearsFailuresOnSuccess(t *testing.T) {
+	proxies := []Proxy{mustProxy(t, "socks5://10.0.0.1:1080")}
+	pool, err := NewPool(nil, proxies, RoundRobin)
+	if err != nil {
+		t.Fatalf("NewPool: %v", err)
+	}
+	pool.failThreshold = 2
+
+	_, h := pool.next()
+	pool.reportResult(h, errors.New("fail"))
+	pool.reportResult(h, nil)
+
+	if h.consecutiveFailures != 0 {
+		t.Errorf("consecutiveFailures = %d, want 0 after success", h.consecutiveFailures)
+	}
+}
@@ -0,0 +1,97 @@
+package dialer
+
+import (
+	"bufio"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// Kind identifies the scheme of a configured upstream proxy.
+type Kind string
+
+const (
+	// KindSOCKS5 resolves the target hostname locally, then connects to the
+	// resulting IP through the proxy (matches curl's "socks5://").
+	KindSOCKS5 Kind = "socks5"
+
+	// KindSOCKS5H leaves hostname resolution to the proxy itself, so a
+	// rotation of proxies in different networks can also diversify DNS exit
+	// points (matches curl's "socks5h://").
+	KindSOCKS5H Kind = "socks5h"
+
+	// KindHTTP tunnels the connection through the proxy with an HTTP CONNECT
+	// request.
+	KindHTTP Kind = "http"
+)
+
+// Proxy is one configured upstream proxy endpoint.
+type Proxy struct {
+	Kind Kind
+	URL  *url.URL
+}
+
+// String returns the proxy's identity for logging and for Result.Proxy
+// (scheme://host:port, with any userinfo stripped).
+func (p Proxy) String() string {
+	stripped := *p.URL
+	stripped.User = nil
+	return stripped.String()
+}
+
+// ParseProxy parses one proxy URL ("socks5://", "socks5h://", or "http://").
+func ParseProxy(raw string) (Proxy, error) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return Proxy{}, fmt.Errorf("invalid proxy URL %q: %w", raw, err)
+	}
+
+	var kind Kind
+	switch strings.ToLower(u.Scheme) {
+	case "socks5":
+		kind = KindSOCKS5
+	case "socks5h":
+		kind = KindSOCKS5H
+	case "http":
+		kind = KindHTTP
+	default:
+		return Proxy{}, fmt.Errorf("proxy %q: unsupported scheme %q (want socks5, socks5h, or http)", raw, u.Scheme)
+	}
+
+	if u.Host == "" {
+		return Proxy{}, fmt.Errorf("proxy %q: missing host", raw)
+	}
+
+	return Proxy{Kind: kind, URL: u}, nil
+}
+
+// LoadProxyFile reads one proxy URL per line from path, skipping blank lines
+// and "#"-prefixed comments.
+func LoadProxyFile(path string) ([]Proxy, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var proxies []Proxy
+	scanner := bufio.NewScanner(f)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		proxy, err := ParseProxy(line)
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, lineNo, err)
+		}
+		proxies = append(proxies, proxy)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return proxies, nil
+}
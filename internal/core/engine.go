@@ -3,6 +3,7 @@ package core
 import (
 	"context"
 	"errors"
+	"iter"
 	"strings"
 	"sync"
 	"time"
@@ -57,6 +58,14 @@ type Result struct {
 	Target       string
 	TimeConsumed time.Duration
 	AttemptedAt  time.Time
+	Proxy        string // Source IP / proxy identity used for this attempt, if the module is dialer-aware
+}
+
+// proxyIdentifier is implemented by modules whose Connect dials through a
+// rotating dialer.Dialer, letting the engine surface which source IP/proxy
+// an attempt actually went out on.
+type proxyIdentifier interface {
+	ProxyIdentity() string
 }
 
 // NewEngine creates a new brute-forcing engine
@@ -113,6 +122,16 @@ func (e *Engine) LoadPasswords(passwords []string) {
 	e.passwordQueue = NewPasswordQueue(passwords)
 }
 
+// LoadPasswordSource loads passwords from a streaming source instead of a
+// materialized slice, so a rule-expanded password set doesn't need to be
+// fully held in memory. total is the declared number of items source will
+// yield and is what Progress() divides by; if source yields fewer (as when
+// total comes from rules.Set.Total, an upper bound), Progress() ends below
+// 1.0.
+func (e *Engine) LoadPasswordSource(source iter.Seq[string], total int) {
+	e.passwordQueue = NewPasswordQueueFromSource(source, total)
+}
+
 // Start begins the brute-forcing process (backward compatible)
 func (e *Engine) Start() error {
 	if e.passwordQueue == nil || e.passwordQueue.Total() == 0 {
@@ -271,6 +290,10 @@ func (e *Engine) worker(id int) {
 			}
 
 			// Create result
+			var proxy string
+			if pi, ok := e.module.(proxyIdentifier); ok {
+				proxy = pi.ProxyIdentity()
+			}
 			result := Result{
 				Username:     e.module.GetUsername(),
 				Password:     password,
@@ -280,6 +303,7 @@ func (e *Engine) worker(id int) {
 				Target:       e.module.GetTarget(),
 				TimeConsumed: elapsed,
 				AttemptedAt:  time.Now(),
+				Proxy:        proxy,
 			}
 
 			// Send result
@@ -339,6 +363,9 @@ func (e *Engine) closeChannels() {
 	defer e.closeMu.Unlock()
 	if !e.closed {
 		e.closed = true
+		if e.passwordQueue != nil {
+			e.passwordQueue.Close()
+		}
 		close(e.results)
 		close(e.errors)
 	}
@@ -5,6 +5,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/nimda/router-brute/internal/core/dialer"
 	"github.com/nimda/router-brute/internal/interfaces"
 	"github.com/nimda/router-brute/pkg/duallog"
 	zlog "github.com/rs/zerolog/log"
@@ -44,6 +45,12 @@ type MultiTargetEngine struct {
 	wg              sync.WaitGroup
 	ctx             context.Context
 	progressTracker *ProgressTracker // Optional progress tracking for resume functionality
+
+	checkpointWriter   *CheckpointWriter           // Optional append-only JSONL checkpoint log
+	checkpointInterval int                         // Attempts between checkpoint flushes for a target
+	resumeCheckpoints  map[string]CheckpointRecord // Loaded checkpoint records, keyed by target IP, for --resume
+
+	dialer *dialer.Dialer // Optional rotating dialer shared across all targets
 }
 
 // NewMultiTargetEngine creates a new multi-target engine
@@ -78,6 +85,22 @@ func (mte *MultiTargetEngine) SetProgressTracker(tracker *ProgressTracker) {
 	mte.progressTracker = tracker
 }
 
+// SetCheckpointWriter configures append-only JSONL checkpoint logging.
+// interval is the number of attempts between checkpoint flushes for a given
+// target; a final record is always written when the target completes.
+func (mte *MultiTargetEngine) SetCheckpointWriter(writer *CheckpointWriter, interval int) {
+	mte.checkpointWriter = writer
+	mte.checkpointInterval = interval
+}
+
+// SetResumeCheckpoints seeds each target's starting password index from a
+// previously loaded checkpoint (see LoadCheckpoint). Callers are expected to
+// have already dropped targets recorded as Completed from the target list;
+// this only resumes partially-attempted targets.
+func (mte *MultiTargetEngine) SetResumeCheckpoints(records map[string]CheckpointRecord) {
+	mte.resumeCheckpoints = records
+}
+
 // SetTimeouts sets the initial and maximum timeouts
 func (mte *MultiTargetEngine) SetTimeouts(initialTimeout, maxTimeout time.Duration) {
 	mte.initialTimeout = initialTimeout
@@ -89,6 +112,12 @@ func (mte *MultiTargetEngine) SetMaxConsecutiveErrors(max int) {
 	mte.maxConsecErrors = max
 }
 
+// SetDialer configures a rotating dialer to be shared across every target's
+// module, for source IP / proxy diversity across the whole multi-target run.
+func (mte *MultiTargetEngine) SetDialer(d *dialer.Dialer) {
+	mte.dialer = d
+}
+
 // Start begins the multi-target attack
 func (mte *MultiTargetEngine) Start(ctx context.Context) {
 	mte.ctx = ctx
@@ -143,9 +172,14 @@ func (mte *MultiTargetEngine) processTarget(target *Target, semaphore chan struc
 
 	// Create module for this target
 	module := mte.moduleFactory.CreateModule()
-	if err := module.Initialize(target.IP, target.Username, map[string]interface{}{
-		"port": target.Port,
-	}); err != nil {
+	options := map[string]interface{}{"port": target.Port}
+	for k, v := range target.Options {
+		options[k] = v
+	}
+	if mte.dialer != nil {
+		options["dialer"] = mte.dialer
+	}
+	if err := module.Initialize(target.IP, target.Username, options); err != nil {
 		zlog.Error().
 			Str("target", target.IP).
 			Err(err).
@@ -200,6 +234,16 @@ func (mte *MultiTargetEngine) processTarget(target *Target, semaphore chan struc
 		}
 	}
 
+	if startPasswordIndex == 0 && mte.resumeCheckpoints != nil {
+		if record, ok := mte.resumeCheckpoints[target.IP]; ok && !record.Completed {
+			startPasswordIndex = record.LastPasswordIndex
+			zlog.Debug().
+				Str("target", target.IP).
+				Int("resume_from", startPasswordIndex).
+				Msg("Resuming from checkpoint file")
+		}
+	}
+
 	// Create engine for this target
 	engine := NewEngine(mte.workersPerTarget, mte.rateLimit)
 	engine.SetModule(module)
@@ -288,6 +332,19 @@ func (mte *MultiTargetEngine) processTarget(target *Target, semaphore chan struc
 					engine.GetConsecutiveErrors(), // consecutive errors
 				)
 			}
+
+			if mte.checkpointWriter != nil && mte.checkpointInterval > 0 && len(results)%mte.checkpointInterval == 0 {
+				totalAttempts := startPasswordIndex + len(results)
+				if err := mte.checkpointWriter.Write(CheckpointRecord{
+					Target:            target.IP,
+					Username:          target.Username,
+					LastPasswordIndex: totalAttempts,
+					Completed:         false,
+					Success:           false,
+				}); err != nil {
+					zlog.Warn().Str("target", target.IP).Err(err).Msg("Failed to write checkpoint")
+				}
+			}
 		}
 		zlog.Debug().Str("target", target.IP).Int("results", len(results)).Msg("Results collected")
 	}()
@@ -342,6 +399,21 @@ func (mte *MultiTargetEngine) processTarget(target *Target, semaphore chan struc
 		)
 	}
 
+	if mte.checkpointWriter != nil {
+		totalAttempts := startPasswordIndex + len(results)
+		// A target cut short by cancellation (SIGINT/SIGTERM) is recorded as
+		// not completed, so --resume picks it up from LastPasswordIndex.
+		if err := mte.checkpointWriter.Write(CheckpointRecord{
+			Target:            target.IP,
+			Username:          target.Username,
+			LastPasswordIndex: totalAttempts,
+			Completed:         success || mte.ctx.Err() == nil,
+			Success:           success,
+		}); err != nil {
+			zlog.Warn().Str("target", target.IP).Err(err).Msg("Failed to write final checkpoint")
+		}
+	}
+
 	mte.resultsChan <- MultiTargetResult{
 		Target:          target,
 		Results:         results,
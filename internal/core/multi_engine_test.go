@@ -206,7 +206,7 @@ func TestMultiTargetEngine_ConcurrentTargets(t *testing.T) {
 
 	// Verify results (order may vary due to concurrency)
 	assert.Len(t, results, 2)
-	
+
 	// Find the results for each target
 	var result1, result2 *MultiTargetResult
 	for _, result := range results {
@@ -216,12 +216,12 @@ func TestMultiTargetEngine_ConcurrentTargets(t *testing.T) {
 			result2 = &result
 		}
 	}
-	
+
 	// First target should fail
 	assert.NotNil(t, result1)
 	assert.False(t, result1.Success)
 	assert.Equal(t, 2, result1.Attempts)
-	
+
 	// Second target should succeed
 	assert.NotNil(t, result2)
 	assert.True(t, result2.Success)
@@ -292,7 +292,7 @@ func TestMultiTargetEngine_Cancellation(t *testing.T) {
 
 func TestMultiTargetEngine_ErrorHandling(t *testing.T) {
 	errExpected := errors.New("initialization failed")
-	
+
 	// Create mock module factory
 	mockFactory := new(MockModuleFactory)
 	mockModule := new(MockRouterModule)
@@ -335,4 +335,4 @@ func TestMultiTargetEngine_ErrorHandling(t *testing.T) {
 
 	mockFactory.AssertExpectations(t)
 	mockModule.AssertExpectations(t)
-}
\ No newline at end of file
+}
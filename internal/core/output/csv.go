@@ -0,0 +1,99 @@
+package output
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// csvResultHeader is written once at the top of the results file.
+var csvResultHeader = []string{
+	"ts", "target", "port", "module", "username", "password",
+	"success", "error", "elapsed_ms", "attempt_idx", "proxy",
+}
+
+// csvSink writes ResultRecords as CSV rows. Progress and summary records are
+// not tabular by nature, so they are written to the same file as a
+// "# progress ..." / "# summary ..." comment line for simple tail -f use.
+type csvSink struct {
+	mu        sync.Mutex
+	file      *os.File
+	writer    *csv.Writer
+	wroteHead bool
+}
+
+// newCSVSink opens path for appending and returns a Sink that writes
+// ResultRecords as CSV rows.
+func newCSVSink(path string) (Sink, error) {
+	_, statErr := os.Stat(path)
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("opening csv sink %q: %w", path, err)
+	}
+	s := &csvSink{file: f, writer: csv.NewWriter(f), wroteHead: statErr == nil}
+	if !s.wroteHead {
+		if err := s.writer.Write(csvResultHeader); err != nil {
+			return nil, fmt.Errorf("writing csv header: %w", err)
+		}
+		s.writer.Flush()
+		s.wroteHead = true
+	}
+	return s, nil
+}
+
+func (s *csvSink) WriteResult(r ResultRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	row := []string{
+		r.Timestamp.Format(time.RFC3339Nano),
+		r.Target,
+		strconv.Itoa(r.Port),
+		r.Module,
+		r.Username,
+		r.Password,
+		strconv.FormatBool(r.Success),
+		r.Error,
+		strconv.FormatInt(r.ElapsedMs, 10),
+		strconv.Itoa(r.AttemptIdx),
+		r.Proxy,
+	}
+	if err := s.writer.Write(row); err != nil {
+		return err
+	}
+	s.writer.Flush()
+	return s.writer.Error()
+}
+
+func (s *csvSink) WriteProgress(p ProgressRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	line := fmt.Sprintf("# progress ts=%s attempts=%d targets=%d/%d successes=%d\n",
+		p.Timestamp.Format(time.RFC3339Nano), p.AttemptsTotal, p.TargetsCompleted, p.TargetsTotal, p.SuccessCount)
+	_, err := s.file.WriteString(line)
+	return err
+}
+
+func (s *csvSink) WriteSummary(sum SummaryRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	line := fmt.Sprintf("# summary ts=%s module=%s targets=%d attempts=%d successes=%d duration=%s\n",
+		sum.Timestamp.Format(time.RFC3339Nano), sum.Module, sum.TotalTargets, sum.TotalAttempts, sum.SuccessCount, sum.Duration)
+	_, err := s.file.WriteString(line)
+	return err
+}
+
+func (s *csvSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.writer.Flush()
+	if err := s.writer.Error(); err != nil {
+		return err
+	}
+	return s.file.Close()
+}
@@ -0,0 +1,25 @@
+package output
+
+import "fmt"
+
+// NewSink creates a Sink that writes to path in the given format
+// ("jsonl", "ndjson", or "csv").
+func NewSink(path string, format string) (Sink, error) {
+	switch format {
+	case "jsonl", "ndjson", "":
+		return newJSONLSink(path)
+	case "csv":
+		return newCSVSink(path)
+	default:
+		return nil, fmt.Errorf("unsupported output format: %q", format)
+	}
+}
+
+// NoopSink discards every record. Use this when no --output/--progress-file
+// flag was supplied.
+type NoopSink struct{}
+
+func (NoopSink) WriteResult(ResultRecord) error     { return nil }
+func (NoopSink) WriteProgress(ProgressRecord) error { return nil }
+func (NoopSink) WriteSummary(SummaryRecord) error   { return nil }
+func (NoopSink) Close() error                       { return nil }
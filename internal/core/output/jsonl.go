@@ -0,0 +1,50 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+)
+
+// jsonlSink writes one JSON object per line. It is used for both the
+// "jsonl" and "ndjson" formats, which are the same on-disk representation.
+type jsonlSink struct {
+	mu   sync.Mutex
+	file *os.File
+	enc  *json.Encoder
+}
+
+// newJSONLSink opens path for appending and returns a Sink that writes one
+// JSON object per line for every record.
+func newJSONLSink(path string) (Sink, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("opening jsonl sink %q: %w", path, err)
+	}
+	return &jsonlSink{file: f, enc: json.NewEncoder(f)}, nil
+}
+
+func (s *jsonlSink) WriteResult(r ResultRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.enc.Encode(r)
+}
+
+func (s *jsonlSink) WriteProgress(p ProgressRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.enc.Encode(p)
+}
+
+func (s *jsonlSink) WriteSummary(sum SummaryRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.enc.Encode(sum)
+}
+
+func (s *jsonlSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.file.Close()
+}
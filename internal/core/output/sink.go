@@ -0,0 +1,58 @@
+// Package output provides pluggable sinks for writing structured,
+// machine-readable records about an attack run (per-attempt results,
+// periodic progress, and a final summary) so downstream tooling can consume
+// runs programmatically instead of screen-scraping zerolog output.
+package output
+
+import "time"
+
+// ResultRecord describes the outcome of a single authentication attempt.
+type ResultRecord struct {
+	Timestamp  time.Time `json:"ts"`
+	Target     string    `json:"target"`
+	Port       int       `json:"port"`
+	Module     string    `json:"module"`
+	Username   string    `json:"username"`
+	Password   string    `json:"password"`
+	Success    bool      `json:"success"`
+	Error      string    `json:"error,omitempty"`
+	ElapsedMs  int64     `json:"elapsed_ms"`
+	AttemptIdx int       `json:"attempt_idx"`
+	Proxy      string    `json:"proxy,omitempty"`
+}
+
+// ProgressRecord is a periodic snapshot of run progress.
+type ProgressRecord struct {
+	Timestamp        time.Time `json:"ts"`
+	AttemptsTotal    int       `json:"attempts_total"`
+	TargetsTotal     int       `json:"targets_total"`
+	TargetsCompleted int       `json:"targets_completed"`
+	SuccessCount     int       `json:"success_count"`
+}
+
+// SummaryRecord is emitted once, after a run finishes.
+type SummaryRecord struct {
+	Timestamp     time.Time     `json:"ts"`
+	Module        string        `json:"module"`
+	TotalTargets  int           `json:"total_targets"`
+	TotalAttempts int           `json:"total_attempts"`
+	SuccessCount  int           `json:"success_count"`
+	Duration      time.Duration `json:"duration"`
+}
+
+// Sink receives structured records about an attack run. Implementations
+// write results, progress, and summary records to some backing store
+// (a JSON lines file, a CSV file, syslog, a webhook, ...).
+type Sink interface {
+	// WriteResult records the outcome of a single authentication attempt.
+	WriteResult(ResultRecord) error
+
+	// WriteProgress records a periodic progress snapshot.
+	WriteProgress(ProgressRecord) error
+
+	// WriteSummary records the final summary of the run.
+	WriteSummary(SummaryRecord) error
+
+	// Close flushes and releases any resources held by the sink.
+	Close() error
+}
@@ -0,0 +1,105 @@
+package output
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestJSONLSinkWriteResult(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "results.jsonl")
+
+	sink, err := NewSink(path, "jsonl")
+	if err != nil {
+		t.Fatalf("NewSink: %v", err)
+	}
+
+	record := ResultRecord{
+		Timestamp:  time.Now(),
+		Target:     "192.168.1.1",
+		Port:       8728,
+		Module:     "mikrotik-v6",
+		Username:   "admin",
+		Password:   "hunter2",
+		Success:    true,
+		ElapsedMs:  42,
+		AttemptIdx: 1,
+	}
+
+	if err := sink.WriteResult(record); err != nil {
+		t.Fatalf("WriteResult: %v", err)
+	}
+	if err := sink.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("opening output file: %v", err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	if !scanner.Scan() {
+		t.Fatal("expected at least one line in output file")
+	}
+
+	var decoded ResultRecord
+	if err := json.Unmarshal(scanner.Bytes(), &decoded); err != nil {
+		t.Fatalf("decoding result record: %v", err)
+	}
+	if decoded.Target != record.Target || decoded.Password != record.Password {
+		t.Errorf("decoded record mismatch: got %+v, want %+v", decoded, record)
+	}
+}
+
+func TestCSVSinkWritesHeaderOnce(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "results.csv")
+
+	sink, err := NewSink(path, "csv")
+	if err != nil {
+		t.Fatalf("NewSink: %v", err)
+	}
+	if err := sink.WriteResult(ResultRecord{Target: "10.0.0.1", AttemptIdx: 1}); err != nil {
+		t.Fatalf("WriteResult: %v", err)
+	}
+	if err := sink.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	// Reopening an existing CSV file must not duplicate the header.
+	sink2, err := NewSink(path, "csv")
+	if err != nil {
+		t.Fatalf("NewSink (reopen): %v", err)
+	}
+	if err := sink2.WriteResult(ResultRecord{Target: "10.0.0.2", AttemptIdx: 2}); err != nil {
+		t.Fatalf("WriteResult: %v", err)
+	}
+	if err := sink2.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading output file: %v", err)
+	}
+
+	lines := 0
+	for _, b := range data {
+		if b == '\n' {
+			lines++
+		}
+	}
+	if lines != 3 {
+		t.Errorf("expected 1 header line + 2 data lines, got %d lines", lines)
+	}
+}
+
+func TestNewSinkUnsupportedFormat(t *testing.T) {
+	if _, err := NewSink(filepath.Join(t.TempDir(), "out"), "xml"); err == nil {
+		t.Error("expected an error for an unsupported format")
+	}
+}
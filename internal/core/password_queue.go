@@ -1,27 +1,76 @@
 package core
 
-import "sync"
+import (
+	"iter"
+	"sync"
+)
 
-// PasswordQueue manages the list of passwords to try
+// PasswordQueue manages the list of passwords to try. It can be backed by a
+// materialized slice (the common case) or by a streaming source (an
+// iter.Seq[string] plus a declared total), so rule-expanded password sets
+// don't need to be fully materialized in memory.
 type PasswordQueue struct {
-	passwords []string
-	index     int
-	mu        sync.Mutex
+	passwords []string // nil when backed by a streaming source
+
+	next func() (string, bool) // non-nil when backed by a streaming source
+	stop func()
+
+	total      int
+	pulled     int
+	last       string
+	haveLast   bool
+	pushedBack bool
+
+	index int
+	mu    sync.Mutex
 }
 
-// NewPasswordQueue creates a new password queue
+// NewPasswordQueue creates a new password queue backed by a materialized
+// slice of passwords.
 func NewPasswordQueue(passwords []string) *PasswordQueue {
 	return &PasswordQueue{
 		passwords: passwords,
+		total:     len(passwords),
 		index:     0,
 	}
 }
 
+// NewPasswordQueueFromSource creates a new password queue backed by a
+// streaming source. total is the declared number of items source will yield;
+// it's used by Progress/Total/Remaining since the source can't be measured
+// without being consumed, so if source yields fewer, Progress ends below 1.0
+// and Remaining above 0.
+func NewPasswordQueueFromSource(source iter.Seq[string], total int) *PasswordQueue {
+	next, stop := iter.Pull(source)
+	return &PasswordQueue{
+		next:  next,
+		stop:  stop,
+		total: total,
+	}
+}
+
 // Next returns the next password in the queue, or empty string if done
 func (pq *PasswordQueue) Next() string {
 	pq.mu.Lock()
 	defer pq.mu.Unlock()
 
+	if pq.pushedBack {
+		pq.pushedBack = false
+		pq.pulled++
+		return pq.last
+	}
+
+	if pq.next != nil {
+		password, ok := pq.next()
+		if !ok {
+			return ""
+		}
+		pq.pulled++
+		pq.last = password
+		pq.haveLast = true
+		return password
+	}
+
 	if pq.index >= len(pq.passwords) {
 		return ""
 	}
@@ -37,12 +86,21 @@ func (pq *PasswordQueue) Unget() {
 	pq.mu.Lock()
 	defer pq.mu.Unlock()
 
+	if pq.next != nil {
+		if pq.haveLast {
+			pq.pushedBack = true
+			pq.pulled--
+		}
+		return
+	}
+
 	if pq.index > 0 {
 		pq.index--
 	}
 }
 
-// Reset resets the queue to the beginning
+// Reset resets the queue to the beginning. Streaming sources can't be
+// rewound, so this is a no-op when the queue is backed by one.
 func (pq *PasswordQueue) Reset() {
 	pq.mu.Lock()
 	defer pq.mu.Unlock()
@@ -54,22 +112,41 @@ func (pq *PasswordQueue) Progress() float64 {
 	pq.mu.Lock()
 	defer pq.mu.Unlock()
 
-	if len(pq.passwords) == 0 {
+	if pq.total == 0 {
 		return 0.0
 	}
-	return float64(pq.index) / float64(len(pq.passwords))
+	if pq.next != nil {
+		return float64(pq.pulled) / float64(pq.total)
+	}
+	return float64(pq.index) / float64(pq.total)
 }
 
 // Total returns the total number of passwords
 func (pq *PasswordQueue) Total() int {
 	pq.mu.Lock()
 	defer pq.mu.Unlock()
-	return len(pq.passwords)
+	return pq.total
 }
 
 // Remaining returns the number of passwords remaining
 func (pq *PasswordQueue) Remaining() int {
 	pq.mu.Lock()
 	defer pq.mu.Unlock()
-	return len(pq.passwords) - pq.index
+
+	if pq.next != nil {
+		return pq.total - pq.pulled
+	}
+	return pq.total - pq.index
+}
+
+// Close releases resources held by a streaming source. It's a no-op for a
+// queue backed by a materialized slice.
+func (pq *PasswordQueue) Close() {
+	pq.mu.Lock()
+	stop := pq.stop
+	pq.mu.Unlock()
+
+	if stop != nil {
+		stop()
+	}
 }
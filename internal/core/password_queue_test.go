@@ -0,0 +1,66 @@
+package core
+
+import "testing"
+
+func TestPasswordQueueFromSource(t *testing.T) {
+	words := []string{"a", "b", "c"}
+	source := func(yield func(string) bool) {
+		for _, w := range words {
+			if !yield(w) {
+				return
+			}
+		}
+	}
+
+	pq := NewPasswordQueueFromSource(source, len(words))
+	defer pq.Close()
+
+	if got := pq.Total(); got != len(words) {
+		t.Fatalf("Total() = %d, want %d", got, len(words))
+	}
+
+	var got []string
+	for {
+		w := pq.Next()
+		if w == "" {
+			break
+		}
+		got = append(got, w)
+	}
+	if len(got) != len(words) {
+		t.Fatalf("got %v, want %v", got, words)
+	}
+	for i, w := range words {
+		if got[i] != w {
+			t.Errorf("got[%d] = %q, want %q", i, got[i], w)
+		}
+	}
+
+	if progress := pq.Progress(); progress != 1.0 {
+		t.Errorf("Progress() = %f, want 1.0", progress)
+	}
+}
+
+func TestPasswordQueueFromSourceUnget(t *testing.T) {
+	words := []string{"a", "b"}
+	source := func(yield func(string) bool) {
+		for _, w := range words {
+			if !yield(w) {
+				return
+			}
+		}
+	}
+
+	pq := NewPasswordQueueFromSource(source, len(words))
+	defer pq.Close()
+
+	first := pq.Next()
+	pq.Unget()
+	second := pq.Next()
+	if first != second {
+		t.Errorf("Unget() did not replay the same word: got %q then %q", first, second)
+	}
+	if remaining := pq.Remaining(); remaining != len(words)-1 {
+		t.Errorf("Remaining() after replay = %d, want %d", remaining, len(words)-1)
+	}
+}
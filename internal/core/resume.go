@@ -12,26 +12,29 @@ import (
 
 // ResumeState represents the complete state of an attack that can be saved and resumed
 type ResumeState struct {
-	Timestamp    time.Time          `json:"timestamp"`
-	Protocol     string             `json:"protocol"`
-	Username     string             `json:"username"`
-	PasswordFile string             `json:"password_file"`
-	TargetFile   string             `json:"target_file,omitempty"` // For multi-target mode
-	Workers      int                `json:"workers"`
-	RateLimit    string             `json:"rate_limit"`
-	Targets      []TargetProgress   `json:"targets"`
+	Timestamp    time.Time              `json:"timestamp"`
+	Protocol     string                 `json:"protocol"`
+	Username     string                 `json:"username"`
+	PasswordFile string                 `json:"password_file"`
+	TargetFile   string                 `json:"target_file,omitempty"` // For multi-target mode
+	Workers      int                    `json:"workers"`
+	RateLimit    string                 `json:"rate_limit"`
+	Targets      []TargetProgress       `json:"targets"`
 	Options      map[string]interface{} `json:"options,omitempty"`
 }
 
 // TargetProgress tracks progress for a single target
 type TargetProgress struct {
-	IP             string `json:"ip"`
-	Port           int    `json:"port"`
-	Username       string `json:"username"`
-	PasswordsTried int    `json:"passwords_tried"` // Number of passwords attempted
-	Completed      bool   `json:"completed"`       // Target finished (success or all passwords tried)
-	Success        bool   `json:"success"`         // Found valid credentials
-	FoundPassword  string `json:"found_password,omitempty"` // The successful password (if any)
+	IP                string `json:"ip"`
+	Port              int    `json:"port"`
+	Username          string `json:"username"`
+	PasswordsTried    int    `json:"passwords_tried"`              // Number of passwords attempted
+	Completed         bool   `json:"completed"`                    // Target finished (success or all passwords tried)
+	Success           bool   `json:"success"`                      // Found valid credentials
+	FoundPassword     string `json:"found_password,omitempty"`     // The successful password (if any)
+	TimeoutMs         int    `json:"timeout_ms,omitempty"`         // Last adaptive timeout observed for this target
+	Dead              bool   `json:"dead,omitempty"`               // Target exceeded the consecutive-error threshold
+	ConsecutiveErrors int    `json:"consecutive_errors,omitempty"` // Consecutive errors seen at last update
 }
 
 // SaveResumeState saves the current attack state to a timestamped file
@@ -109,8 +112,10 @@ func (rs *ResumeState) GetRemainingTargets() []TargetProgress {
 	return remaining
 }
 
-// UpdateTargetProgress updates the progress for a specific target
-func (rs *ResumeState) UpdateTargetProgress(ip string, port int, passwordsTried int, completed bool, success bool, foundPassword string) {
+// UpdateTargetProgress updates the progress for a specific target, including
+// the adaptive-timeout and dead-host bookkeeping tracked alongside password
+// progress.
+func (rs *ResumeState) UpdateTargetProgress(ip string, port int, passwordsTried int, completed bool, success bool, foundPassword string, timeoutMs int, dead bool, consecutiveErrors int) {
 	for i := range rs.Targets {
 		if rs.Targets[i].IP == ip && rs.Targets[i].Port == port {
 			rs.Targets[i].PasswordsTried = passwordsTried
@@ -119,6 +124,9 @@ func (rs *ResumeState) UpdateTargetProgress(ip string, port int, passwordsTried
 			if success {
 				rs.Targets[i].FoundPassword = foundPassword
 			}
+			rs.Targets[i].TimeoutMs = timeoutMs
+			rs.Targets[i].Dead = dead
+			rs.Targets[i].ConsecutiveErrors = consecutiveErrors
 			return
 		}
 	}
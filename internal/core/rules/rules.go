@@ -0,0 +1,403 @@
+// Package rules implements a hashcat-compatible subset of mangling rules
+// (and a couple of non-hashcat generator shorthands) applied to a base
+// wordlist to produce an expanded password candidate set, without
+// materializing that set in memory.
+package rules
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// ErrNotApplicable is returned by a positional op (T, D, i) that addresses
+// past the end of the word. Hashcat's behavior in this case is to discard
+// the candidate rather than emit a partial transform, so Rule.Apply does the
+// same: it yields no candidate for that word instead of returning an error.
+var ErrNotApplicable = errors.New("rule not applicable to word")
+
+// Expr is one parsed --rule expression: either a hashcat mangling rule
+// (one word in, one word out) or a suffix generator (one word in, many
+// words out).
+type Expr interface {
+	// Apply returns the candidate(s) produced from word. It may return an
+	// empty slice (a Rule that doesn't apply to this word).
+	Apply(word string) []string
+
+	// Count returns how many candidates Apply produces per input word, used
+	// to declare a total up front for a streaming PasswordSource.
+	Count() int
+
+	String() string
+}
+
+// Parse parses one rule expression. Hashcat rule syntax (e.g. "c", "d $1",
+// "so0", "T3") is dispatched to ParseRule; "year:YYYY-YYYY" and "digits:N"
+// are handled as suffix generators.
+func Parse(expr string) (Expr, error) {
+	switch {
+	case strings.HasPrefix(expr, "year:"):
+		return parseYearGenerator(expr)
+	case strings.HasPrefix(expr, "digits:"):
+		return parseDigitsGenerator(expr)
+	default:
+		return ParseRule(expr)
+	}
+}
+
+// ParseFile reads one rule expression per line from path, skipping blank
+// lines and "#"-prefixed comments, in the style of a hashcat .rule file.
+func ParseFile(path string) ([]Expr, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var exprs []Expr
+	scanner := bufio.NewScanner(f)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		expr, err := Parse(line)
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, lineNo, err)
+		}
+		exprs = append(exprs, expr)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return exprs, nil
+}
+
+// Rule is a parsed hashcat-compatible mangling rule: an ordered sequence of
+// single-op transforms applied to one input word to produce one output
+// word.
+type Rule struct {
+	raw string
+	ops []op
+}
+
+type op struct {
+	code byte
+	args []byte
+}
+
+func (r Rule) String() string { return r.raw }
+
+// Count always reports 1: a Rule maps one input word to at most one output
+// word.
+func (r Rule) Count() int { return 1 }
+
+// Apply returns the word transformed by r, or an empty slice if an op in r
+// isn't applicable to word (e.g. a position past the end of a short word).
+func (r Rule) Apply(word string) []string {
+	w := []byte(word)
+	for _, o := range r.ops {
+		out, err := applyOp(o, w)
+		if err != nil {
+			return nil
+		}
+		w = out
+	}
+	return []string{string(w)}
+}
+
+// ParseRule parses one hashcat-style rule expression, e.g. "c", "$1", "so0",
+// "T3". Ops may be separated by spaces; supported ops are:
+//
+//	:      no-op
+//	l      lowercase the whole word
+//	u      uppercase the whole word
+//	c      capitalize (first char upper, rest lower)
+//	C      invert capitalize (first char lower, rest upper)
+//	r      reverse
+//	d      duplicate (word -> wordword)
+//	$X     append char X
+//	^X     prepend char X
+//	sXY    substitute every X with Y
+//	[      delete the first char
+//	]      delete the last char
+//	TN     toggle the case of the char at position N
+//	DN     delete the char at position N
+//	iNX    insert char X at position N
+//
+// Positions (N) use hashcat's 0-9, A-Z charset for 0-35.
+func ParseRule(expr string) (Rule, error) {
+	raw := expr
+	compact := strings.ReplaceAll(expr, " ", "")
+	if compact == "" {
+		return Rule{}, fmt.Errorf("rule %q: empty", raw)
+	}
+
+	var ops []op
+	i := 0
+	for i < len(compact) {
+		c := compact[i]
+		switch c {
+		case ':', 'l', 'u', 'c', 'C', 'r', 'd', '[', ']':
+			ops = append(ops, op{code: c})
+			i++
+		case '$', '^':
+			if i+1 >= len(compact) {
+				return Rule{}, fmt.Errorf("rule %q: %q requires an argument", raw, string(c))
+			}
+			ops = append(ops, op{code: c, args: []byte{compact[i+1]}})
+			i += 2
+		case 's':
+			if i+2 >= len(compact) {
+				return Rule{}, fmt.Errorf("rule %q: %q requires two arguments", raw, string(c))
+			}
+			ops = append(ops, op{code: c, args: []byte{compact[i+1], compact[i+2]}})
+			i += 3
+		case 'T', 'D':
+			if i+1 >= len(compact) {
+				return Rule{}, fmt.Errorf("rule %q: %q requires a position argument", raw, string(c))
+			}
+			ops = append(ops, op{code: c, args: []byte{compact[i+1]}})
+			i += 2
+		case 'i':
+			if i+2 >= len(compact) {
+				return Rule{}, fmt.Errorf("rule %q: %q requires position and character arguments", raw, string(c))
+			}
+			ops = append(ops, op{code: c, args: []byte{compact[i+1], compact[i+2]}})
+			i += 3
+		default:
+			return Rule{}, fmt.Errorf("rule %q: unsupported op %q", raw, string(c))
+		}
+	}
+	return Rule{raw: raw, ops: ops}, nil
+}
+
+func applyOp(o op, w []byte) ([]byte, error) {
+	switch o.code {
+	case ':':
+		return w, nil
+	case 'l':
+		return toLower(w), nil
+	case 'u':
+		return toUpper(w), nil
+	case 'c':
+		return capitalize(w), nil
+	case 'C':
+		return invertCapitalize(w), nil
+	case 'r':
+		return reverse(w), nil
+	case 'd':
+		out := make([]byte, 0, len(w)*2)
+		out = append(out, w...)
+		out = append(out, w...)
+		return out, nil
+	case '$':
+		return append(append([]byte{}, w...), o.args[0]), nil
+	case '^':
+		return append([]byte{o.args[0]}, w...), nil
+	case 's':
+		return substitute(w, o.args[0], o.args[1]), nil
+	case '[':
+		if len(w) == 0 {
+			return w, nil
+		}
+		return w[1:], nil
+	case ']':
+		if len(w) == 0 {
+			return w, nil
+		}
+		return w[:len(w)-1], nil
+	case 'T':
+		pos, ok := decodePos(o.args[0])
+		if !ok || pos >= len(w) {
+			return nil, ErrNotApplicable
+		}
+		out := append([]byte{}, w...)
+		out[pos] = toggleCase(out[pos])
+		return out, nil
+	case 'D':
+		pos, ok := decodePos(o.args[0])
+		if !ok || pos >= len(w) {
+			return nil, ErrNotApplicable
+		}
+		out := make([]byte, 0, len(w)-1)
+		out = append(out, w[:pos]...)
+		out = append(out, w[pos+1:]...)
+		return out, nil
+	case 'i':
+		pos, ok := decodePos(o.args[0])
+		if !ok || pos > len(w) {
+			return nil, ErrNotApplicable
+		}
+		out := make([]byte, 0, len(w)+1)
+		out = append(out, w[:pos]...)
+		out = append(out, o.args[1])
+		out = append(out, w[pos:]...)
+		return out, nil
+	default:
+		return nil, fmt.Errorf("unknown op %q", string(o.code))
+	}
+}
+
+func decodePos(b byte) (int, bool) {
+	switch {
+	case b >= '0' && b <= '9':
+		return int(b - '0'), true
+	case b >= 'A' && b <= 'Z':
+		return int(b-'A') + 10, true
+	default:
+		return 0, false
+	}
+}
+
+func toLower(w []byte) []byte {
+	out := append([]byte{}, w...)
+	for i, b := range out {
+		if b >= 'A' && b <= 'Z' {
+			out[i] = b + ('a' - 'A')
+		}
+	}
+	return out
+}
+
+func toUpper(w []byte) []byte {
+	out := append([]byte{}, w...)
+	for i, b := range out {
+		if b >= 'a' && b <= 'z' {
+			out[i] = b - ('a' - 'A')
+		}
+	}
+	return out
+}
+
+func toggleCase(b byte) byte {
+	switch {
+	case b >= 'a' && b <= 'z':
+		return b - ('a' - 'A')
+	case b >= 'A' && b <= 'Z':
+		return b + ('a' - 'A')
+	default:
+		return b
+	}
+}
+
+func capitalize(w []byte) []byte {
+	out := toLower(w)
+	if len(out) > 0 {
+		out[0] = toggleCase(out[0])
+	}
+	return out
+}
+
+func invertCapitalize(w []byte) []byte {
+	out := toUpper(w)
+	if len(out) > 0 {
+		out[0] = toggleCase(out[0])
+	}
+	return out
+}
+
+func reverse(w []byte) []byte {
+	out := make([]byte, len(w))
+	for i, b := range w {
+		out[len(w)-1-i] = b
+	}
+	return out
+}
+
+func substitute(w []byte, from, to byte) []byte {
+	out := append([]byte{}, w...)
+	for i, b := range out {
+		if b == from {
+			out[i] = to
+		}
+	}
+	return out
+}
+
+// yearGenerator appends every year in [from, to] to the input word.
+type yearGenerator struct {
+	raw      string
+	from, to int
+}
+
+func parseYearGenerator(expr string) (Expr, error) {
+	body := strings.TrimPrefix(expr, "year:")
+	lo, hi, ok := strings.Cut(body, "-")
+	if !ok {
+		return nil, fmt.Errorf("generator %q: expected year:YYYY-YYYY", expr)
+	}
+	from, err := strconv.Atoi(lo)
+	if err != nil {
+		return nil, fmt.Errorf("generator %q: invalid start year: %w", expr, err)
+	}
+	to, err := strconv.Atoi(hi)
+	if err != nil {
+		return nil, fmt.Errorf("generator %q: invalid end year: %w", expr, err)
+	}
+	if to < from {
+		return nil, fmt.Errorf("generator %q: end year before start year", expr)
+	}
+	return yearGenerator{raw: expr, from: from, to: to}, nil
+}
+
+func (g yearGenerator) String() string { return g.raw }
+func (g yearGenerator) Count() int     { return g.to - g.from + 1 }
+
+func (g yearGenerator) Apply(word string) []string {
+	out := make([]string, 0, g.Count())
+	for y := g.from; y <= g.to; y++ {
+		out = append(out, word+strconv.Itoa(y))
+	}
+	return out
+}
+
+// maxDigitsSuffix caps digits:N so a single --rule can't blow up memory by
+// requesting an absurd number of candidates per word.
+const maxDigitsSuffix = 6
+
+// digitsGenerator appends every zero-padded N-digit number to the input
+// word, e.g. digits:2 appends "00".."99".
+type digitsGenerator struct {
+	raw string
+	n   int
+}
+
+func parseDigitsGenerator(expr string) (Expr, error) {
+	body := strings.TrimPrefix(expr, "digits:")
+	n, err := strconv.Atoi(body)
+	if err != nil {
+		return nil, fmt.Errorf("generator %q: invalid digit count: %w", expr, err)
+	}
+	if n <= 0 {
+		return nil, fmt.Errorf("generator %q: digit count must be positive", expr)
+	}
+	if n > maxDigitsSuffix {
+		return nil, fmt.Errorf("generator %q: digit count %d exceeds max of %d", expr, n, maxDigitsSuffix)
+	}
+	return digitsGenerator{raw: expr, n: n}, nil
+}
+
+func (g digitsGenerator) String() string { return g.raw }
+
+func (g digitsGenerator) Count() int {
+	count := 1
+	for i := 0; i < g.n; i++ {
+		count *= 10
+	}
+	return count
+}
+
+func (g digitsGenerator) Apply(word string) []string {
+	format := "%0" + strconv.Itoa(g.n) + "d"
+	out := make([]string, 0, g.Count())
+	for i := 0; i < g.Count(); i++ {
+		out = append(out, word+fmt.Sprintf(format, i))
+	}
+	return out
+}
@@ -0,0 +1,132 @@
+package rules
+
+import "testing"
+
+// Reference vectors from hashcat's rule engine documentation
+// (docs/rule_based_attack.md): each case is one op applied to its input
+// word ("Password", or a case variant of it).
+func TestRuleApplyHashcatReferenceVectors(t *testing.T) {
+	cases := []struct {
+		rule string
+		in   string
+		want string
+	}{
+		{":", "Password", "Password"},
+		{"l", "Password", "password"},
+		{"u", "Password", "PASSWORD"},
+		{"c", "PASSWORD", "Password"},
+		{"C", "password", "pASSWORD"},
+		{"r", "Password", "drowssaP"},
+		{"d", "Password", "PasswordPassword"},
+		{"$1", "Password", "Password1"},
+		{"^1", "Password", "1Password"},
+		{"sa4", "Password", "P4ssword"},
+		{"[", "Password", "assword"},
+		{"]", "Password", "Passwor"},
+		{"T0", "password", "Password"},
+		{"D0", "Password", "assword"},
+		{"i0!", "Password", "!Password"},
+	}
+
+	for _, tc := range cases {
+		rule, err := ParseRule(tc.rule)
+		if err != nil {
+			t.Fatalf("ParseRule(%q): %v", tc.rule, err)
+		}
+		got := rule.Apply(tc.in)
+		if len(got) != 1 || got[0] != tc.want {
+			t.Errorf("rule %q applied to %q = %v, want [%q]", tc.rule, tc.in, got, tc.want)
+		}
+	}
+}
+
+func TestRuleApplyNotApplicable(t *testing.T) {
+	rule, err := ParseRule("T5")
+	if err != nil {
+		t.Fatalf("ParseRule: %v", err)
+	}
+	if got := rule.Apply("ab"); got != nil {
+		t.Errorf("Apply() with out-of-range position = %v, want nil", got)
+	}
+}
+
+func TestParseRuleRejectsUnknownOp(t *testing.T) {
+	if _, err := ParseRule("q"); err == nil {
+		t.Fatal("expected error for unsupported op")
+	}
+}
+
+func TestYearGenerator(t *testing.T) {
+	expr, err := Parse("year:2023-2025")
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if expr.Count() != 3 {
+		t.Fatalf("Count() = %d, want 3", expr.Count())
+	}
+	got := expr.Apply("admin")
+	want := []string{"admin2023", "admin2024", "admin2025"}
+	if len(got) != len(want) {
+		t.Fatalf("Apply() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Apply()[%d] = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
+
+func TestDigitsGenerator(t *testing.T) {
+	expr, err := Parse("digits:2")
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if expr.Count() != 100 {
+		t.Fatalf("Count() = %d, want 100", expr.Count())
+	}
+	got := expr.Apply("admin")
+	if got[0] != "admin00" || got[99] != "admin99" {
+		t.Errorf("Apply() endpoints = %q, %q, want admin00, admin99", got[0], got[99])
+	}
+}
+
+func TestDigitsGeneratorRejectsTooLarge(t *testing.T) {
+	if _, err := Parse("digits:10"); err == nil {
+		t.Fatal("expected error for digit count over the max")
+	}
+}
+
+func TestSetSourceChainsAllExprsAcrossAllWords(t *testing.T) {
+	set := NewSet()
+	if err := set.AddExpr("u"); err != nil {
+		t.Fatalf("AddExpr: %v", err)
+	}
+	if err := set.AddExpr("$!"); err != nil {
+		t.Fatalf("AddExpr: %v", err)
+	}
+
+	words := []string{"abc", "xyz"}
+	got := set.Expand(words)
+	want := []string{"ABC", "abc!", "XYZ", "xyz!"}
+	if len(got) != len(want) {
+		t.Fatalf("Expand() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Expand()[%d] = %q, want %q", i, got[i], want[i])
+		}
+	}
+
+	if total := set.Total(len(words)); total != len(want) {
+		t.Errorf("Total() = %d, want %d", total, len(want))
+	}
+}
+
+func TestSetSourceWithNoExprsPassesWordsThrough(t *testing.T) {
+	set := NewSet()
+	words := []string{"a", "b"}
+	got := set.Expand(words)
+	if len(got) != 2 || got[0] != "a" || got[1] != "b" {
+		t.Errorf("Expand() with empty set = %v, want %v", got, words)
+	}
+}
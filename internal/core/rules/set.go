@@ -0,0 +1,98 @@
+package rules
+
+import "iter"
+
+// Set is an ordered collection of rule expressions (loaded from --rules
+// files and/or repeated --rule flags) applied to a base wordlist.
+type Set struct {
+	exprs []Expr
+}
+
+// NewSet creates an empty rule set.
+func NewSet() *Set {
+	return &Set{}
+}
+
+// Add appends one already-parsed expression.
+func (s *Set) Add(expr Expr) {
+	s.exprs = append(s.exprs, expr)
+}
+
+// AddExpr parses expr and appends it.
+func (s *Set) AddExpr(expr string) error {
+	parsed, err := Parse(expr)
+	if err != nil {
+		return err
+	}
+	s.Add(parsed)
+	return nil
+}
+
+// AddFile parses every rule expression in the file at path and appends them.
+func (s *Set) AddFile(path string) error {
+	exprs, err := ParseFile(path)
+	if err != nil {
+		return err
+	}
+	s.exprs = append(s.exprs, exprs...)
+	return nil
+}
+
+// Len returns the number of loaded expressions.
+func (s *Set) Len() int {
+	return len(s.exprs)
+}
+
+// Total returns the declared candidate count for numWords base words run
+// through every loaded expression. If no expressions are loaded, the base
+// words pass through unchanged and the total is just numWords.
+//
+// This is an upper bound, not an exact count: a Rule whose positional op
+// doesn't apply to a given word (see ErrNotApplicable) yields no candidate
+// for that word, so the actual stream may be shorter than Total reports.
+func (s *Set) Total(numWords int) int {
+	if len(s.exprs) == 0 {
+		return numWords
+	}
+	perWord := 0
+	for _, e := range s.exprs {
+		perWord += e.Count()
+	}
+	return perWord * numWords
+}
+
+// Source returns a streaming iterator over every candidate produced by
+// running words through every loaded expression, in order. If no
+// expressions are loaded, it yields words unchanged.
+func (s *Set) Source(words []string) iter.Seq[string] {
+	return func(yield func(string) bool) {
+		if len(s.exprs) == 0 {
+			for _, w := range words {
+				if !yield(w) {
+					return
+				}
+			}
+			return
+		}
+		for _, w := range words {
+			for _, e := range s.exprs {
+				for _, candidate := range e.Apply(w) {
+					if !yield(candidate) {
+						return
+					}
+				}
+			}
+		}
+	}
+}
+
+// Expand materializes the candidate set as a slice. Use for call sites
+// (like MultiTargetEngine) that still take a []string rather than a
+// streaming PasswordSource.
+func (s *Set) Expand(words []string) []string {
+	out := make([]string, 0, s.Total(len(words)))
+	for candidate := range s.Source(words) {
+		out = append(out, candidate)
+	}
+	return out
+}
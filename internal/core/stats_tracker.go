@@ -10,20 +10,20 @@ import (
 
 // StatsTracker tracks attack statistics and outputs progress reports
 type StatsTracker struct {
-	mu                  sync.RWMutex
-	startTime           time.Time
-	totalPasswords      int
-	totalTargets        int
-	passwordsTried      int
-	targetsCompleted    int
-	targetsAlive        int // Non-dead targets
-	outputInterval      time.Duration
-	stopChan            chan struct{}
-	wg                  sync.WaitGroup
-	progressTracker     *ProgressTracker
-	lastPasswordsTried  int
-	lastReportTime      time.Time
-	currentSpeed        float64 // passwords per second
+	mu                 sync.RWMutex
+	startTime          time.Time
+	totalPasswords     int
+	totalTargets       int
+	passwordsTried     int
+	targetsCompleted   int
+	targetsAlive       int // Non-dead targets
+	outputInterval     time.Duration
+	stopChan           chan struct{}
+	wg                 sync.WaitGroup
+	progressTracker    *ProgressTracker
+	lastPasswordsTried int
+	lastReportTime     time.Time
+	currentSpeed       float64 // passwords per second
 }
 
 // NewStatsTracker creates a new statistics tracker
@@ -16,6 +16,11 @@ type Target struct {
 	IP       string
 	Port     int
 	Command  string
+
+	// Options carries extra per-target module options (e.g. "https" for
+	// mikrotik-v7-rest) merged into the module's Initialize call alongside
+	// the standard "port" option. Nil for targets without overrides.
+	Options map[string]interface{}
 }
 
 // TargetParser handles parsing of target specifications from files
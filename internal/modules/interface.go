@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"github.com/nimda/router-brute/internal/core"
+	"github.com/nimda/router-brute/internal/core/dialer"
 	"github.com/nimda/router-brute/internal/interfaces"
 	"time"
 )
@@ -14,6 +15,7 @@ type BaseRouterModule struct {
 	username  string
 	options   map[string]interface{}
 	connected bool
+	dialer    *dialer.Dialer // Optional rotating dialer for source IP / proxy diversity
 }
 
 // NewBaseRouterModule creates a new base router module
@@ -35,9 +37,29 @@ func (b *BaseRouterModule) Initialize(target, username string, options map[strin
 		}
 	}
 
+	if d, ok := options["dialer"].(*dialer.Dialer); ok {
+		b.dialer = d
+	}
+
 	return nil
 }
 
+// GetDialer returns the rotating dialer configured for this module via the
+// "dialer" Initialize option, or nil if none was configured.
+func (b *BaseRouterModule) GetDialer() *dialer.Dialer {
+	return b.dialer
+}
+
+// ProxyIdentity returns the source IP / proxy used by the most recent dial,
+// for surfacing on core.Result. It satisfies the proxy identity interface
+// that Engine looks for without every module needing to implement it itself.
+func (b *BaseRouterModule) ProxyIdentity() string {
+	if b.dialer == nil {
+		return ""
+	}
+	return b.dialer.LastIdentity()
+}
+
 // GetTarget returns the target
 func (b *BaseRouterModule) GetTarget() string {
 	return b.target
@@ -98,5 +120,6 @@ func (b *BaseRouterModule) CreateResult(password string, success bool, err error
 		ModuleName:  b.GetProtocolName(),
 		Target:      b.target,
 		AttemptedAt: time.Now(),
+		Proxy:       b.ProxyIdentity(),
 	}
 }
@@ -74,12 +74,15 @@ func (m *MikrotikV6Module) Connect(ctx context.Context) error {
 
 	address := fmt.Sprintf("%s:%d", m.GetTarget(), m.port)
 
-	// Set up connection with timeout
-	dialer := &net.Dialer{
-		Timeout: m.timeout,
+	// Dial through the configured rotating dialer if one was supplied,
+	// otherwise fall back to a plain direct dial.
+	var conn net.Conn
+	var err error
+	if d := m.GetDialer(); d != nil {
+		conn, err = d.DialContext(ctx, "tcp", address)
+	} else {
+		conn, err = (&net.Dialer{Timeout: m.timeout}).DialContext(ctx, "tcp", address)
 	}
-
-	conn, err := dialer.DialContext(ctx, "tcp", address)
 	if err != nil {
 		return utils.NewConnectionError(m.GetTarget(), err)
 	}
@@ -85,7 +85,17 @@ func (m *MikrotikV7Module) Initialize(target, username string, options map[strin
 		zlog.Debug().Str("webfig_url", m.webfigURL).Msg("RouterOS v7 WebFig URL")
 	}
 
-	return m.BaseRouterModule.Initialize(target, username, options)
+	if err := m.BaseRouterModule.Initialize(target, username, options); err != nil {
+		return err
+	}
+
+	if m.useWebFig {
+		if d := m.GetDialer(); d != nil {
+			m.httpClient.Transport = d.RoundTripper()
+		}
+	}
+
+	return nil
 }
 
 // Connect establishes a connection to the Mikrotik router using RouterOS v7 protocol
@@ -112,12 +122,15 @@ func (m *MikrotikV7Module) Connect(ctx context.Context) error {
 	address := fmt.Sprintf("%s:%d", m.GetTarget(), m.port)
 	zlog.Trace().Str("address", address).Str("timeout", m.timeout.String()).Msg("Attempting RouterOS v7 binary connection")
 
-	// Set up connection with timeout
-	dialer := &net.Dialer{
-		Timeout: m.timeout,
+	// Dial through the configured rotating dialer if one was supplied,
+	// otherwise fall back to a plain direct dial.
+	var conn net.Conn
+	var err error
+	if d := m.GetDialer(); d != nil {
+		conn, err = d.DialContext(ctx, "tcp", address)
+	} else {
+		conn, err = (&net.Dialer{Timeout: m.timeout}).DialContext(ctx, "tcp", address)
 	}
-
-	conn, err := dialer.DialContext(ctx, "tcp", address)
 	if err != nil {
 		zlog.Trace().Err(err).Str("address", address).Msg("RouterOS v7 binary connection failed")
 		return utils.NewConnectionError(m.GetTarget(), err)
@@ -84,7 +84,15 @@ func (m *MikrotikV7RestModule) Initialize(target, username string, options map[s
 		Timeout: m.timeout,
 	}
 
-	return m.BaseRouterModule.Initialize(target, username, options)
+	if err := m.BaseRouterModule.Initialize(target, username, options); err != nil {
+		return err
+	}
+
+	if d := m.GetDialer(); d != nil {
+		m.httpClient.Transport = d.RoundTripper()
+	}
+
+	return nil
 }
 
 // Connect establishes a connection to the Mikrotik router using REST API
@@ -0,0 +1,260 @@
+package winbox
+
+import (
+	"context"
+	"crypto/md5"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/nimda/router-brute/internal/interfaces"
+	"github.com/nimda/router-brute/internal/modules"
+	"github.com/nimda/router-brute/pkg/utils"
+	zlog "github.com/rs/zerolog/log"
+)
+
+// handshakeMode identifies which Winbox login handshake a target speaks.
+type handshakeMode int
+
+const (
+	handshakeUnknown handshakeMode = iota
+	handshakeLegacy                // pre-6.43: MD5(0x00 || password || salt)
+	handshakeModern                // 6.43+: Curve25519 SRP-like exchange
+)
+
+// Winbox greeting bytes sent to probe the login handshake the server supports.
+// Legacy servers respond with a 1-byte index command followed by a 16-byte salt;
+// modern servers respond with the mproxy/SRP preamble starting with 0x78.
+var winboxGreeting = []byte{0x01, 0x00}
+
+const (
+	legacySaltLen  = 16
+	modernPreamble = 0x78
+)
+
+// MikrotikWinboxModule implements the Winbox (TCP/8291) management protocol.
+type MikrotikWinboxModule struct {
+	*modules.BaseRouterModule
+	mu      sync.Mutex // Protects conn and authentication operations
+	conn    net.Conn
+	port    int
+	timeout time.Duration
+	mode    handshakeMode
+}
+
+// NewMikrotikWinboxModule creates a new Winbox module.
+func NewMikrotikWinboxModule() *MikrotikWinboxModule {
+	return &MikrotikWinboxModule{
+		BaseRouterModule: modules.NewBaseRouterModule(),
+		port:             8291, // Default Winbox port
+		timeout:          10 * time.Second,
+	}
+}
+
+// GetProtocolName returns the protocol name
+func (m *MikrotikWinboxModule) GetProtocolName() string {
+	return "mikrotik-winbox"
+}
+
+// Initialize sets up the module with target information
+func (m *MikrotikWinboxModule) Initialize(target, username string, options map[string]interface{}) error {
+	if port, ok := options["port"]; ok {
+		if p, err := strconv.Atoi(fmt.Sprintf("%v", port)); err == nil {
+			m.port = p
+		}
+	}
+
+	if timeout, ok := options["timeout"]; ok {
+		if t, err := time.ParseDuration(fmt.Sprintf("%v", timeout)); err == nil {
+			m.timeout = t
+		}
+	}
+
+	return m.BaseRouterModule.Initialize(target, username, options)
+}
+
+// Connect establishes a connection to the Winbox service
+func (m *MikrotikWinboxModule) Connect(ctx context.Context) error {
+	if m.IsConnected() {
+		return nil
+	}
+
+	if ctx == nil {
+		return errors.New("nil context")
+	}
+
+	address := fmt.Sprintf("%s:%d", m.GetTarget(), m.port)
+
+	// Dial through the configured rotating dialer if one was supplied,
+	// otherwise fall back to a plain direct dial.
+	var conn net.Conn
+	var err error
+	if d := m.GetDialer(); d != nil {
+		conn, err = d.DialContext(ctx, "tcp", address)
+	} else {
+		conn, err = (&net.Dialer{Timeout: m.timeout}).DialContext(ctx, "tcp", address)
+	}
+	if err != nil {
+		return utils.NewConnectionError(m.GetTarget(), err)
+	}
+
+	m.conn = conn
+	m.mode = handshakeUnknown
+	m.SetConnected(true)
+
+	if err := m.conn.SetDeadline(time.Now().Add(m.timeout)); err != nil {
+		if closeErr := m.conn.Close(); closeErr != nil {
+			zlog.Trace().Err(closeErr).Msg("Error closing winbox connection after deadline failure")
+		}
+		m.SetConnected(false)
+		return err
+	}
+
+	return nil
+}
+
+// Close cleans up the connection
+func (m *MikrotikWinboxModule) Close() error {
+	if !m.IsConnected() {
+		return nil
+	}
+
+	if m.conn != nil {
+		if err := m.conn.Close(); err != nil {
+			zlog.Trace().Err(err).Msg("Error closing winbox connection")
+		}
+	}
+	m.SetConnected(false)
+	m.conn = nil
+	m.mode = handshakeUnknown
+	return nil
+}
+
+// Authenticate attempts to authenticate with the given password, auto-detecting
+// whether the target speaks the legacy MD5 handshake or the modern Curve25519 one.
+func (m *MikrotikWinboxModule) Authenticate(ctx context.Context, password string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if ctx == nil {
+		return false, errors.New("nil context received in Authenticate()")
+	}
+
+	if !m.IsConnected() {
+		if err := m.Connect(ctx); err != nil {
+			return false, err
+		}
+	}
+
+	if err := m.conn.SetDeadline(time.Now().Add(m.timeout)); err != nil {
+		return false, err
+	}
+
+	mode, challenge, err := m.detectHandshake()
+	if err != nil {
+		if closeErr := m.Close(); closeErr != nil {
+			zlog.Trace().Err(closeErr).Msg("Error closing winbox connection after detect failure")
+		}
+		return false, err
+	}
+	m.mode = mode
+
+	var success bool
+	switch mode {
+	case handshakeLegacy:
+		success, err = m.legacyLogin(challenge, m.GetUsername(), password)
+	case handshakeModern:
+		success, err = m.modernLogin(challenge, m.GetUsername(), password)
+	default:
+		err = errors.New("unable to detect winbox handshake mode")
+	}
+
+	if err != nil {
+		if closeErr := m.Close(); closeErr != nil {
+			zlog.Trace().Err(closeErr).Msg("Error closing winbox connection after auth error")
+		}
+		return false, err
+	}
+
+	// The connection can only be used for one login attempt; reconnect next time.
+	if closeErr := m.Close(); closeErr != nil {
+		zlog.Trace().Err(closeErr).Msg("Error closing winbox connection after auth attempt")
+	}
+
+	return success, nil
+}
+
+// detectHandshake sends the initial greeting and inspects the server's reply
+// to determine which login handshake is in use, returning the challenge bytes
+// needed to complete it (16-byte salt for legacy, pubkey+salt for modern).
+func (m *MikrotikWinboxModule) detectHandshake() (handshakeMode, []byte, error) {
+	if _, err := m.conn.Write(winboxGreeting); err != nil {
+		return handshakeUnknown, nil, err
+	}
+
+	buf := make([]byte, 256)
+	n, err := m.conn.Read(buf)
+	if err != nil {
+		return handshakeUnknown, nil, err
+	}
+	if n == 0 {
+		return handshakeUnknown, nil, errors.New("empty winbox greeting response")
+	}
+
+	reply := buf[:n]
+
+	if reply[0] == modernPreamble {
+		return handshakeModern, reply[1:], nil
+	}
+
+	if len(reply) >= legacySaltLen {
+		return handshakeLegacy, reply[len(reply)-legacySaltLen:], nil
+	}
+
+	return handshakeUnknown, nil, fmt.Errorf("unrecognized winbox handshake reply (%d bytes)", n)
+}
+
+// legacyLogin performs the pre-6.43 MD5(0x00 || password || salt) handshake.
+func (m *MikrotikWinboxModule) legacyLogin(salt []byte, username, password string) (bool, error) {
+	if len(salt) != legacySaltLen {
+		return false, fmt.Errorf("invalid legacy salt length: %d", len(salt))
+	}
+
+	response := legacyResponse(password, salt)
+
+	msg := append([]byte(username), 0x00)
+	msg = append(msg, response...)
+	if _, err := m.conn.Write(msg); err != nil {
+		return false, err
+	}
+
+	buf := make([]byte, 256)
+	n, err := m.conn.Read(buf)
+	if err != nil {
+		return false, err
+	}
+
+	return isLegacyAuthAccepted(buf[:n]), nil
+}
+
+// legacyResponse computes the pre-6.43 Winbox login response: MD5(0x00 || password || salt).
+func legacyResponse(password string, salt []byte) []byte {
+	h := md5.New()
+	h.Write([]byte{0x00})
+	h.Write([]byte(password))
+	h.Write(salt)
+	return h.Sum(nil)
+}
+
+// isLegacyAuthAccepted inspects the server's reply for a legacy acceptance marker.
+// A zero first byte means the login was accepted; any non-zero value is an
+// error code carrying the failure reason.
+func isLegacyAuthAccepted(reply []byte) bool {
+	return len(reply) > 0 && reply[0] == 0x00
+}
+
+// Ensure MikrotikWinboxModule implements the RouterModule interface
+var _ interfaces.RouterModule = (*MikrotikWinboxModule)(nil)
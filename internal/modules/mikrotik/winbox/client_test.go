@@ -0,0 +1,72 @@
+package winbox
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+)
+
+func TestMikrotikWinboxModuleCreation(t *testing.T) {
+	module := NewMikrotikWinboxModule()
+
+	if module == nil {
+		t.Fatal("Failed to create MikrotikWinboxModule")
+	}
+
+	if module.GetProtocolName() != "mikrotik-winbox" {
+		t.Errorf("Expected protocol name 'mikrotik-winbox', got '%s'", module.GetProtocolName())
+	}
+
+	err := module.Initialize("192.168.1.1", "admin", map[string]interface{}{
+		"port":    8291,
+		"timeout": "5s",
+	})
+	if err != nil {
+		t.Errorf("Failed to initialize module: %v", err)
+	}
+
+	if module.GetTarget() != "192.168.1.1" {
+		t.Errorf("Expected target '192.168.1.1', got '%s'", module.GetTarget())
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	if err := module.Connect(ctx); err == nil {
+		t.Error("Expected connection to fail (no real router), but it succeeded")
+	}
+}
+
+func TestLegacyResponse(t *testing.T) {
+	salt := bytes.Repeat([]byte{0xAA}, legacySaltLen)
+	resp := legacyResponse("password123", salt)
+
+	if len(resp) != 16 {
+		t.Fatalf("Expected 16-byte MD5 digest, got %d bytes", len(resp))
+	}
+
+	// Same inputs must produce the same response.
+	resp2 := legacyResponse("password123", salt)
+	if !bytes.Equal(resp, resp2) {
+		t.Error("legacyResponse is not deterministic for identical inputs")
+	}
+
+	// Different passwords must produce different responses.
+	resp3 := legacyResponse("different", salt)
+	if bytes.Equal(resp, resp3) {
+		t.Error("legacyResponse produced identical output for different passwords")
+	}
+}
+
+func TestIsLegacyAuthAccepted(t *testing.T) {
+	if !isLegacyAuthAccepted([]byte{0x00, 0x01}) {
+		t.Error("Expected acceptance for leading zero byte")
+	}
+	if isLegacyAuthAccepted([]byte{0x01}) {
+		t.Error("Expected rejection for non-zero leading byte")
+	}
+	if isLegacyAuthAccepted(nil) {
+		t.Error("Expected rejection for empty reply")
+	}
+}
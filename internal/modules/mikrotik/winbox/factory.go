@@ -0,0 +1,18 @@
+package winbox
+
+import (
+	"github.com/nimda/router-brute/internal/interfaces"
+)
+
+// MikrotikWinboxFactory creates Mikrotik Winbox modules
+type MikrotikWinboxFactory struct{}
+
+// CreateModule creates a new MikrotikWinboxModule instance
+func (f *MikrotikWinboxFactory) CreateModule() interfaces.RouterModule {
+	return NewMikrotikWinboxModule()
+}
+
+// GetProtocolName returns the protocol name
+func (f *MikrotikWinboxFactory) GetProtocolName() string {
+	return "mikrotik-winbox"
+}
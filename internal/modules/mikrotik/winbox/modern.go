@@ -0,0 +1,127 @@
+package winbox
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"fmt"
+	"io"
+
+	"golang.org/x/crypto/curve25519"
+	"golang.org/x/crypto/hkdf"
+)
+
+const (
+	modernSaltLen = 16
+	modernKeyLen  = 32 // AES-256-CTR key
+	modernMACLen  = 32 // HMAC-SHA256 key
+)
+
+// modernLogin performs the 6.43+ SRP-like Curve25519 handshake. The server's
+// public key and salt are taken from its reply to the greeting, before the
+// client has sent a key of its own; the client then derives a shared
+// AES-CTR/HMAC key pair from the ECDH secret and sends its ephemeral public
+// key, the username and its proof of knowledge of H(salt || password)
+// (AES-CTR encrypted) in one message, and reads a single reply.
+func (m *MikrotikWinboxModule) modernLogin(serverHello []byte, username, password string) (bool, error) {
+	serverPub, salt, err := parseModernHello(serverHello)
+	if err != nil {
+		return false, err
+	}
+
+	var clientPriv [32]byte
+	if _, err := io.ReadFull(rand.Reader, clientPriv[:]); err != nil {
+		return false, fmt.Errorf("generating winbox ephemeral key: %w", err)
+	}
+	clientPub, err := curve25519.X25519(clientPriv[:], curve25519.Basepoint)
+	if err != nil {
+		return false, fmt.Errorf("deriving winbox public key: %w", err)
+	}
+
+	shared, err := curve25519.X25519(clientPriv[:], serverPub)
+	if err != nil {
+		return false, fmt.Errorf("computing winbox shared secret: %w", err)
+	}
+
+	encKey, macKey, err := deriveModernKeys(shared, salt)
+	if err != nil {
+		return false, err
+	}
+
+	proof := modernProof(salt, password, macKey)
+
+	request := append([]byte{}, clientPub...)
+	request = append(request, []byte(username)...)
+	request = append(request, 0x00)
+	ciphertext, err := encryptModern(encKey, proof)
+	if err != nil {
+		return false, err
+	}
+	request = append(request, ciphertext...)
+
+	if _, err := m.conn.Write(request); err != nil {
+		return false, err
+	}
+
+	buf := make([]byte, 256)
+	n, err := m.conn.Read(buf)
+	if err != nil {
+		return false, err
+	}
+
+	return isModernAuthAccepted(buf[:n]), nil
+}
+
+// parseModernHello splits the server's handshake reply into its Curve25519
+// public key and salt.
+func parseModernHello(reply []byte) (pub, salt []byte, err error) {
+	if len(reply) < 32+modernSaltLen {
+		return nil, nil, fmt.Errorf("short winbox modern hello: %d bytes", len(reply))
+	}
+	return reply[:32], reply[32 : 32+modernSaltLen], nil
+}
+
+// deriveModernKeys HKDF-expands the ECDH shared secret into an AES-CTR
+// encryption key and an HMAC-SHA256 authentication key.
+func deriveModernKeys(shared, salt []byte) (encKey, macKey []byte, err error) {
+	kdf := hkdf.New(sha256.New, shared, salt, []byte("winbox-login"))
+	keys := make([]byte, modernKeyLen+modernMACLen)
+	if _, err := io.ReadFull(kdf, keys); err != nil {
+		return nil, nil, fmt.Errorf("hkdf expand: %w", err)
+	}
+	return keys[:modernKeyLen], keys[modernKeyLen:], nil
+}
+
+// modernProof computes HMAC(macKey, H(salt || password)), proving knowledge of
+// the password without ever sending it in the clear.
+func modernProof(salt []byte, password string, macKey []byte) []byte {
+	h := sha256.New()
+	h.Write(salt)
+	h.Write([]byte(password))
+	digest := h.Sum(nil)
+
+	mac := hmac.New(sha256.New, macKey)
+	mac.Write(digest)
+	return mac.Sum(nil)
+}
+
+// encryptModern wraps the proof in AES-CTR using a fixed-zero nonce, matching
+// the single-message-per-connection nature of the login exchange.
+func encryptModern(key, plaintext []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	iv := make([]byte, aes.BlockSize)
+	stream := cipher.NewCTR(block, iv)
+	ciphertext := make([]byte, len(plaintext))
+	stream.XORKeyStream(ciphertext, plaintext)
+	return ciphertext, nil
+}
+
+// isModernAuthAccepted inspects the server's reply for an acceptance marker.
+func isModernAuthAccepted(reply []byte) bool {
+	return len(reply) > 0 && reply[0] == 0x00
+}
@@ -0,0 +1,59 @@
+package winbox
+
+import (
+	"time"
+
+	"github.com/nimda/router-brute/internal/interfaces"
+)
+
+// Option is a functional option for configuring MikrotikWinboxModule.
+type Option func(*MikrotikWinboxModule)
+
+// WithPort sets the port number.
+func WithPort(port int) Option {
+	return func(m *MikrotikWinboxModule) {
+		m.port = port
+	}
+}
+
+// WithTimeout sets the connection timeout.
+func WithTimeout(timeout time.Duration) Option {
+	return func(m *MikrotikWinboxModule) {
+		m.timeout = timeout
+	}
+}
+
+// WithConfig applies a ModuleConfig to the module.
+func WithConfig(cfg *interfaces.ModuleConfig) Option {
+	return func(m *MikrotikWinboxModule) {
+		if cfg.Port > 0 {
+			m.port = cfg.Port
+		}
+		if cfg.Timeout > 0 {
+			m.timeout = cfg.Timeout
+		}
+	}
+}
+
+// NewMikrotikWinboxModuleWithOptions creates a new module with functional options.
+func NewMikrotikWinboxModuleWithOptions(opts ...Option) *MikrotikWinboxModule {
+	m := NewMikrotikWinboxModule()
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// DefaultPort returns the default port for MikroTik Winbox.
+const DefaultPort = 8291
+
+func init() {
+	// Register this protocol with the default registry
+	_ = interfaces.Register(interfaces.ProtocolInfo{
+		Name:         "mikrotik-winbox",
+		Description:  "MikroTik Winbox management protocol (legacy MD5 and modern Curve25519 handshakes)",
+		DefaultPort:  DefaultPort,
+		Factory:      func() interfaces.RouterModule { return NewMikrotikWinboxModule() },
+		MultiFactory: &MikrotikWinboxFactory{},
+	})
+}